@@ -0,0 +1,83 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Audit log of bean lookups by Bean(), Inject() and other methods searching beans by type, see WithAccessLog
+ */
+type BeanAccessLog interface {
+
+	/**
+		Called on each lookup of the bean by type.
+		Caller is the class of the object injected on runtime, nil if the bean is requested directly.
+	 */
+	LogAccess(caller reflect.Type, requested reflect.Type, found bool, duration time.Duration)
+}
+
+/**
+	Report each lookup of the bean by type in to the log
+ */
+func WithAccessLog(log BeanAccessLog) Option {
+	return func(conf *contextConfig) {
+		conf.accessLog = log
+	}
+}
+
+/**
+	Lookup of the bean recorded by MemoryAccessLog
+ */
+type AccessEntry struct {
+	Caller    reflect.Type
+	Requested reflect.Type
+	Found     bool
+	Duration  time.Duration
+}
+
+/**
+	Access log that keeps all entries in memory, safe for concurrent use
+ */
+type MemoryAccessLog struct {
+	mu      sync.Mutex
+	entries []AccessEntry
+}
+
+func (t *MemoryAccessLog) LogAccess(caller reflect.Type, requested reflect.Type, found bool, duration time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries = append(t.entries, AccessEntry{caller, requested, found, duration})
+}
+
+/**
+	Copy of recorded entries in the order of access
+ */
+func (t *MemoryAccessLog) Entries() []AccessEntry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]AccessEntry(nil), t.entries...)
+}
@@ -0,0 +1,63 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestMemoryAccessLog(t *testing.T) {
+
+	context.Verbose = false
+
+	log := &context.MemoryAccessLog{}
+	ctx, err := context.CreateWithOptions([]interface{}{&configStorage{}, &configServiceImpl{}}, context.WithAccessLog(log))
+	require.Nil(t, err)
+	require.Empty(t, log.Entries())
+
+	for i := 0; i < 10; i++ {
+		ctx.Bean(StorageClass)
+	}
+	entries := log.Entries()
+	require.Equal(t, 10, len(entries))
+	for _, e := range entries {
+		require.Nil(t, e.Caller)
+		require.Equal(t, StorageClass, e.Requested)
+		require.True(t, e.Found)
+	}
+
+	holder := &struct{ UserService UserService `inject:"optional"` }{}
+	_, err = ctx.Inject(holder)
+	require.Nil(t, err)
+
+	entries = log.Entries()
+	require.Equal(t, 11, len(entries))
+	last := entries[10]
+	require.Equal(t, reflect.TypeOf(holder), last.Caller)
+	require.Equal(t, UserServiceClass, last.Requested)
+	require.False(t, last.Found)
+
+}
@@ -0,0 +1,172 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Error returned by Create() if the interface required by injections has two or more candidates
+ */
+type AmbiguityError struct {
+	/**
+		Interface that has multiple implementations
+	 */
+	Interface   reflect.Type
+
+	/**
+		Types of beans that implement the interface, sorted by name
+	 */
+	Candidates  []reflect.Type
+
+	cause       error
+	diag        *ambiguityDiagnostic
+}
+
+func (t *AmbiguityError) Error() string {
+	return t.cause.Error()
+}
+
+/**
+	Explain the ambiguity with candidates, injections and suggestions how to resolve it, the same as ExplainAmbiguity()
+ */
+func (t *AmbiguityError) Explain() string {
+	return t.diag.String()
+}
+
+type ambiguityDiagnostic struct {
+	/**
+		Interface that has multiple implementations
+	 */
+	ifaceType   reflect.Type
+
+	/**
+		Types of beans that implement the interface
+	 */
+	candidates  []reflect.Type
+
+	/**
+		Inject fields that require the interface
+	 */
+	injects     []*injectionDef
+}
+
+/**
+	Find types of all beans in core that could implement the interface, sorted by name
+ */
+func findCandidates(ifaceType reflect.Type, core map[reflect.Type]*bean) []reflect.Type {
+	var candidates []reflect.Type
+	for serviceTyp, service := range core {
+		if service.beanDef.implements(ifaceType) {
+			candidates = append(candidates, serviceTyp)
+		}
+	}
+	sortTypes(candidates)
+	return candidates
+}
+
+func newAmbiguityError(cause error, ifaceType reflect.Type, candidates []reflect.Type, injects []*injection) *AmbiguityError {
+	diag := &ambiguityDiagnostic{ifaceType: ifaceType, candidates: candidates}
+	for _, inject := range injects {
+		diag.injects = append(diag.injects, inject.injectionDef)
+	}
+	return &AmbiguityError{
+		Interface:  ifaceType,
+		Candidates: candidates,
+		cause:      cause,
+		diag:       diag,
+	}
+}
+
+func (t *context) ExplainAmbiguity(ifaceType reflect.Type) string {
+	core := t.coreBeans()
+	diag := &ambiguityDiagnostic{
+		ifaceType:  ifaceType,
+		candidates: findCandidates(ifaceType, core),
+	}
+	for _, b := range core {
+		for _, f := range b.beanDef.fields {
+			if f.fieldType == ifaceType {
+				diag.injects = append(diag.injects, f)
+			}
+		}
+	}
+	sort.Slice(diag.injects, func(i, j int) bool {
+		return diag.injects[i].String() < diag.injects[j].String()
+	})
+	return diag.String()
+}
+
+func (t *ambiguityDiagnostic) String() string {
+	var out strings.Builder
+	if len(t.candidates) < 2 {
+		fmt.Fprintf(&out, "interface '%v' is not ambiguous, found %d candidates %v\n", t.ifaceType, len(t.candidates), t.candidates)
+		return out.String()
+	}
+	fmt.Fprintf(&out, "interface '%v' has %d candidates:\n", t.ifaceType, len(t.candidates))
+	for _, candidate := range t.candidates {
+		fmt.Fprintf(&out, "	'%v' implements all methods of '%v'", candidate, t.ifaceType)
+		if candidate.Kind() == reflect.Ptr && candidate.Elem().Kind() == reflect.Struct {
+			var anonymous []string
+			class := candidate.Elem()
+			for j := 0; j < class.NumField(); j++ {
+				if field := class.Field(j); field.Anonymous {
+					anonymous = append(anonymous, field.Type.String())
+				}
+			}
+			if len(anonymous) > 0 {
+				fmt.Fprintf(&out, " and none of anonymous fields %v is of this type", anonymous)
+			} else {
+				out.WriteString(" and does not have anonymous fields to exclude")
+			}
+		}
+		out.WriteString("\n")
+	}
+	if len(t.injects) > 0 {
+		out.WriteString("required by injections:\n")
+		for _, inject := range t.injects {
+			fmt.Fprintf(&out, "	field '%s' of type '%v' in '%v'\n", inject.fieldName, inject.fieldType, inject.class)
+		}
+	}
+	out.WriteString("suggestions:\n")
+	fmt.Fprintf(&out, "	Primary: inject the primary candidate by pointer type instead of the interface, for example %s %v `inject`\n", t.fieldName(), t.candidates[0])
+	out.WriteString("	Named: register candidates under names by context.NewContextFromMap()\n")
+	fmt.Fprintf(&out, "	Qualifier: select the named candidate on the inject field by tag, for example %s %v `inject:\"name\"`\n", t.fieldName(), t.ifaceType)
+	out.WriteString("	remove all candidates except one from the scan list\n")
+	return out.String()
+}
+
+/**
+	Name of the first inject field that requires the interface, used in suggestions
+ */
+func (t *ambiguityDiagnostic) fieldName() string {
+	if len(t.injects) > 0 {
+		return t.injects[0].fieldName
+	}
+	return "Field"
+}
+
@@ -0,0 +1,98 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"errors"
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"log"
+	"os"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestExplainAmbiguity(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+
+	ctx, err := context.Create(
+		logger,
+		&storageImpl{},
+		&memoryStorage{},
+		&configServiceImpl{},
+	)
+	require.NotNil(t, err)
+	require.Nil(t, ctx)
+
+	var ambiguity *context.AmbiguityError
+	require.True(t, errors.As(err, &ambiguity))
+	require.Equal(t, StorageClass, ambiguity.Interface)
+	require.Len(t, ambiguity.Candidates, 2)
+
+	explanation := ambiguity.Explain()
+	require.Contains(t, explanation, "*context_test.storageImpl")
+	require.Contains(t, explanation, "*context_test.memoryStorage")
+	require.Contains(t, explanation, "field 'Storage'")
+	require.Contains(t, explanation, "context_test.configServiceImpl")
+	require.Contains(t, explanation, "Primary: inject the primary candidate by pointer type instead of the interface, for example Storage *context_test.memoryStorage `inject`")
+	require.Contains(t, explanation, "Named: register candidates under names by context.NewContextFromMap()")
+	require.Contains(t, explanation, "Qualifier: select the named candidate on the inject field by tag, for example Storage context_test.Storage `inject:\"name\"`")
+
+}
+
+func TestExplainNoAmbiguity(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+
+	ctx, err := context.Create(
+		logger,
+		&storageImpl{},
+		&configServiceImpl{},
+	)
+	require.Nil(t, err)
+	require.Contains(t, ctx.ExplainAmbiguity(StorageClass), "is not ambiguous")
+
+}
+
+func TestQualifier(t *testing.T) {
+
+	context.Verbose = false
+
+	primary := &configStorage{}
+	secondary := &memoryStorage{}
+	consumer := &namedConsumer{}
+
+	ctx, err := context.NewContextFromMap(map[string]interface{}{
+		"primary":   primary,
+		"secondary": secondary,
+		"consumer":  consumer,
+	})
+	require.Nil(t, err)
+	defer ctx.Close()
+
+	require.Equal(t, Storage(primary), consumer.Primary)
+	require.Equal(t, Storage(secondary), consumer.Secondary)
+
+}
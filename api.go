@@ -18,7 +18,12 @@
 
 package context
 
-import "reflect"
+import (
+	stdcontext "context"
+	"os"
+	"reflect"
+	"time"
+)
 
 /**
 @author Alex Shvid
@@ -27,18 +32,138 @@ import "reflect"
 var Verbose = true
 
 
+/**
+	Context is safe for concurrent use after Create() returns.
+	Core is replaced only as a whole by RecreateBean() and AtomicReplace(), registry and runtime cache are guarded by locks,
+	so Bean(), Lookup() and Inject() could be called from many goroutines.
+	Close() must not be called concurrently with injections.
+ */
+
 type Context interface {
+	/**
+		Context is usable anywhere the standard context.Context is expected.
+		Value(key) returns the bean if key is reflect.Type, Done() is closed on Close().
+		Err() returns context.Canceled after Done() is closed, see WithBaseContext() to derive from the standard context.
+	 */
+	stdcontext.Context
+
 	/**
 		Destroy all beans that implement interface DisposableBean.
+		Only the first call destroys beans, repeated calls return the same error.
 	 */
 	Close() error
 
+	/**
+		Start Close() in background and return the channel that receives the result.
+		Repeated calls return the same channel.
+	 */
+	AsyncClose() <-chan error
+
+	/**
+		Register channel that is closed by Close() before any Destroy() call, to stop dependent goroutines.
+		Each channel is closed only once.
+	 */
+	NotifyOnClose(ch chan<- struct{})
+
+	/**
+		Unregister channel added by NotifyOnClose()
+	 */
+	RemoveOnClose(ch chan<- struct{})
+
+	/**
+		Block until a signal arrives, then Close() the context, but do not wait longer than timeout for it.
+		Returns nil without closing if the context is closed by somebody else before the signal.
+
+		Example:
+			signals := make(chan os.Signal, 1)
+			signal.Notify(signals, syscall.SIGTERM, os.Interrupt)
+			if err := ctx.GracefulClose(signals, 30 * time.Second); err != nil {
+				log.Printf("close: %v", err)
+			}
+	 */
+	GracefulClose(signals <-chan os.Signal, timeout time.Duration) error
+
+	/**
+		Block until Close() finishes, returns the error passed to the cancel function of CreateContext()
+	 */
+	Wait() error
+
+	/**
+		Call Destroy() on the tracked prototype and stop tracking it in Drain(), returns error if the object is not tracked.
+		Prototypes are objects of non-singleton factories and objects created by WireNew() or AutoWire(), see WithPrototypeTracking()
+	 */
+	Release(obj interface{}) error
+
+	/**
+		Block until all tracked disposable prototypes are released and all child contexts created by Fork() are closed.
+		Returns as soon as nothing is tracked, objects tracked after that are waited by the next call.
+	 */
+	Drain() error
+
+	/**
+		Same as Drain(), but returns error if not drained in time
+	 */
+	DrainWithTimeout(d time.Duration) error
+
+	/**
+		Make the context immutable, Inject(), Mock(), AtomicReplace(), RecreateBean(), Restore() and Decorate() return ErrContextFrozen.
+		Read-only operations continue to work. Repeated calls are no-op.
+	 */
+	Freeze()
+
+	/**
+		Returns true after Freeze()
+	 */
+	IsFrozen() bool
+
+	/**
+		Call Destroy() and PostConstruct() again on all initializing beans in order of initialization.
+		Injections are not changed. Safe to call concurrently, for example from a signal handler.
+	 */
+	Refresh() error
+
 	/**
 		Get list of all registered instances on creation of context with scope 'core'
 	 */
 
 	Core() []reflect.Type
 
+	/**
+		Number of beans in core of this context and all parent contexts
+	 */
+
+	BeanCount() int
+
+	/**
+		Number of beans in core of this context, same as len(Core())
+	 */
+
+	LocalBeanCount() int
+
+	/**
+		Check that core contains exactly the listed classes, used by architecture tests to pin the set of beans
+	 */
+
+	RequireExactly(types ...reflect.Type) error
+
+	/**
+		Get list of all instances with scope 'core' in the order of initialization
+	 */
+
+	OrderedCore() []reflect.Type
+
+	/**
+		Partition beans in core by the key returned by classifier for the class of bean,
+		beans in each group are in the order of initialization.
+
+		Example:
+			byPackage := ctx.GroupBy(func(typ reflect.Type) string {
+				return typ.Elem().PkgPath()
+			})
+	 */
+
+	GroupBy(classifier func(reflect.Type) string) map[string][]interface{}
+
 	/**
 		Gets obj by type, that is a pointer to the structure or interface.
 
@@ -58,6 +183,30 @@ type Context interface {
 	 */
 	MustBean(typ reflect.Type) interface{}
 
+	/**
+		Same as Bean(), but returns SentinelError if the context is creating and the bean is not registered yet,
+		or permanent error if the bean is not found after creation
+	 */
+	TryBean(typ reflect.Type) (interface{}, error)
+
+	/**
+		Explain resolution of the type without side effects, returns class of the bean and the algorithm that found it,
+		one of ResolutionDirectPointer, ResolutionInterfaceSearch or ResolutionNamedLookup.
+
+		Example:
+			resolved, via, err := ctx.Resolve(reflect.TypeOf((*app.UserService)(nil)).Elem())
+	 */
+
+	Resolve(typ reflect.Type) (resolved reflect.Type, via string, err error)
+
+	/**
+		Find the type under which the instance was registered in the core, by pointer equality.
+
+		Example:
+			typ, ok := ctx.BeanFor(userService)
+	 */
+	BeanFor(instance interface{}) (reflect.Type, bool)
+
 
 	/**
 		Lookup registered beans in context by name.
@@ -67,23 +216,276 @@ type Context interface {
 			beans := ctx.Bean("app.UserService")
 	 */
 
-	Lookup(iface string) []interface{}
+	Lookup(iface string) BeanSlice
+
+	/**
+		Panic if no beans registered under the name, otherwise same as Lookup()
+	 */
+
+	MustLookup(iface string) BeanSlice
+
+	/**
+		Same as Lookup(), but beans are sorted by the comparator, the sort is stable
+
+		Example:
+			middleware := ctx.SortedLookup("app.Middleware", func(a, b interface{}) bool {
+				return a.(app.Middleware).Priority() < b.(app.Middleware).Priority()
+			})
+	 */
+
+	SortedLookup(iface string, less func(a, b interface{}) bool) BeanSlice
+
+	/**
+		Find all beans in core implementing the interface in the order of scan list
+
+		Example:
+			handlers := ctx.FindAll(reflect.TypeOf((*http.Handler)(nil)).Elem())
+	 */
+
+	FindAll(ifaceType reflect.Type) BeanSlice
+
+	/**
+		Get classes of beans in core implementing the interface sorted by class name, without the beans themselves
+
+		Example:
+			caches := ctx.BeanTypesImplementing(reflect.TypeOf((*app.Cache)(nil)).Elem())
+	 */
+
+	BeanTypesImplementing(ifaceType reflect.Type) []reflect.Type
+
+	/**
+		Get all registered lookup names in lexicographic order
+	 */
+
+	BeanNames() []string
+
+	/**
+		Get copy of all registered lookup names with the beans, changes of the result do not affect the context
+	 */
+
+	LookupAllNames() map[string][]interface{}
+
+	/**
+		Returns true if wiring trace is dumped on failure of creation, see WithDebugOnFailure
+	 */
+
+	Debug() bool
+
+	/**
+		Timing of injections and PostConstruct() calls of beans made by Create(), in the order of steps
+	 */
+
+	Trace() Trace
+
+	/**
+		Register beans on runtime, for example loaded from a plugin.
+		Fields of the beans are injected on runtime and PostConstruct() is called, Destroy() is called on Close().
+		Provided beans are found by Bean() and Lookup(), but they are not part of core and were not injected on creation of context.
+	 */
+
+	Provide(beans ...interface{}) error
 
 	/**
 		Inject fields in to the obj on runtime.
 		Does not add a new obj in to the core context, so this method is only for one-time use with scope 'runtime'.
+		Returns names of injected fields in the order of injection, optional fields without implementation are skipped.
 
 		Example:
 			type requestProcessor struct {
 				app.UserService  `inject`
+				app.Cache        `inject:"optional"`
 			}
 
 			rp := new(requestProcessor)
-			ctx.Inject(rp)
+			injected, err := ctx.Inject(rp)
 			required.NotNil(t, rp.UserService)
 	 */
 
-	Inject(interface{}) error
+	Inject(interface{}) (injected []string, err error)
+
+	/**
+		Report of inject fields of the obj, populated or nil, and whether the values are beans of this context.
+		Useful for debugging of partially injected objects.
+	 */
+
+	Inspect(obj interface{}) (InjectionReport, error)
+
+	/**
+		Populate cache of bean descriptions for Inject() with all beans in core,
+		or with the given classes, for example request-scoped objects that are not registered in the context.
+		Returns error if the class is not a pointer to struct or has invalid tags.
+	 */
+
+	Warmup(classes ...reflect.Type) error
+
+	/**
+		Register obj under the type instead of the current bean, used in tests
+	 */
+
+	Mock(typ reflect.Type, obj interface{}) error
+
+	/**
+		Replace the registered bean only if the current value is pointer-equal to expected.
+		Returns false without error if the current value differs.
+	 */
+
+	AtomicReplace(expected, replacement interface{}) (bool, error)
+
+	/**
+		Replace the bean by a new instance of its class wired on runtime, used for hot-reload of a single bean
+	 */
+
+	RecreateBean(typ reflect.Type) error
+
+	/**
+		Capture the state of registered beans
+	 */
+
+	Snapshot() ContextSnapshot
+
+	/**
+		Restore the state of registered beans from the snapshot
+	 */
+
+	Restore(s ContextSnapshot) error
+
+	/**
+		Explain why the interface has two or more implementations in the context.
+		Create() returns nil context together with *AmbiguityError that gives the same explanation.
+
+		Example:
+			ctx, err := context.Create(...)
+			var ambiguity *context.AmbiguityError
+			if errors.As(err, &ambiguity) {
+				fmt.Println(ambiguity.Explain())
+			}
+	 */
+
+	ExplainAmbiguity(ifaceType reflect.Type) string
+
+	/**
+		Get list of all registered type-to-implementation mappings sorted by type name.
+		Types cached by Bean() and other lookups on runtime are not listed, so the result does not depend on earlier lookups.
+	 */
+
+	Bindings() []Binding
+
+	/**
+		Get current lifecycle phase of the context
+	 */
+
+	Phase() Phase
+
+	/**
+		Compare wiring of this context with the other one, including inject fields of common beans wired to beans of different classes
+	 */
+
+	Diff(other Context) WiringDiff
+
+	/**
+		Get statistics of the context
+	 */
+
+	Stats() Stats
+
+	/**
+		Aggregated health of all beans implementing HealthIndicator
+	 */
+
+	Health() HealthReport
+
+	/**
+		Register observer of lifecycle events of the context, see WithObserver for events of Create()
+	 */
+
+	Observe(observer ContextObserver)
+
+	/**
+		Get dependency graph of beans in core, where edges are beans injected on creation of context
+	 */
+
+	Graph() Graph
+
+	/**
+		Get adjacency map of beans in core to the types of their inject fields in the order of declaration
+	 */
+
+	InjectionGraph() map[reflect.Type][]reflect.Type
+
+	/**
+		Get inject fields of all beans in core sorted by class name, for static analysis of dependencies
+	 */
+
+	Scan() []ScanResult
+
+	/**
+		Get exported description of the bean by type for introspection
+	 */
+
+	GetBeanDefinition(typ reflect.Type) (BeanDefinition, bool)
+
+	/**
+		Get metadata of the bean provided by MetadataProvider, nil if bean not found or has no metadata
+	 */
+
+	BeanMetadata(typ reflect.Type) map[string]string
+
+	/**
+		Find beans in core which metadata contains the key-value pair
+
+		Example:
+			beans := ctx.FindByMetadata("team", "payments")
+	 */
+
+	FindByMetadata(key, value string) []interface{}
+
+	/**
+		Get beans declared in the package or its sub-packages, useful for architectural tests.
+
+		Example:
+			require.Empty(t, ctx.ExtractBeans("github.com/consensusdb/app/http"))
+	 */
+
+	ExtractBeans(pkg string) []interface{}
+
+	/**
+		Create child context with the new beans.
+		Beans not found in the child context are searched in this context.
+		Closing of the child context does not close this context.
+
+		Example:
+			requestCtx, err := ctx.Fork(&requestHandler{})
+	 */
+
+	Fork(scan ...interface{}) (Context, error)
+
+	/**
+		Create a copy of the context sharing beans in core, but with independent registry and runtime cache.
+		Mock(), AtomicReplace() and Provide() on the copy do not affect this context, useful for isolation of tests.
+		Close() of the copy does not destroy beans, they are owned by this context.
+
+		Example:
+			testCtx := ctx.Clone()
+			testCtx.Mock(StorageClass, &mockStorage{})
+	 */
+
+	Clone() Context
+
+	/**
+		Returns a copy of the context with the key-value pair, analog of context.WithValue from standard library.
+		The copy shares beans with the original context, the value is available by Value(key) only in the copy.
+
+		Example:
+			requestCtx := ctx.With("requestID", "abc123")
+	 */
+
+	With(key, value interface{}) Context
+
+	/**
+		Get parent context for child context created by Fork(), nil for root context
+	 */
+
+	Unwrap() Context
 
 }
 
@@ -93,6 +495,12 @@ type Context interface {
 	ObjectType can be pointer to structure or interface.
 
 	Singleton means that object would be created only once.
+
+	The factory is a bean of the context too, its inject fields are wired before Object() is called,
+	therefore the factory could depend on beans of the context except products of other factories.
+
+	If Singleton() returns false, Object() is called on each call of the provider field func() T,
+	where T is ObjectType(). Such objects are wired on runtime and tracked until Release(), see Drain().
  */
 
 type FactoryBean interface {
@@ -114,6 +522,32 @@ type FactoryBean interface {
 
 }
 
+/**
+	Factory bean that could fail to create the object.
+
+	Create() registers the object returned by Object() next to the factory
+	and propagates the error with the type of the factory. Wiring is the same as for FactoryBean.
+ */
+
+type FactoryBeanV2 interface {
+
+	/**
+		Create actual object or return error
+	 */
+	Object() (interface{}, error)
+
+	/**
+		Get object interface or pointer on struct
+	 */
+	ObjectType() reflect.Type
+
+	/**
+		Must be a single object in context
+	 */
+	Singleton() bool
+
+}
+
 
 /**
 	Initializing bean context is using to run required method on post-construct injection stage
@@ -129,6 +563,35 @@ type InitializingBean interface {
 
 }
 
+/**
+	Initializing bean that receives cancellation signal, for example on timeout of creation of context
+ */
+
+type ContextInitializingBean interface {
+
+	/**
+		Runs this method automatically after initializing and injecting context
+	 */
+
+	PostConstruct(ctx stdcontext.Context) error
+
+}
+
+/**
+	Bean that provides free-form metadata, for example ownership, version or feature flags.
+	Metadata is collected once on creation of context.
+ */
+
+type MetadataProvider interface {
+
+	/**
+		Get metadata of the bean
+	 */
+
+	Metadata() map[string]string
+
+}
+
 /**
 	This interface uses to select objects that could free resources after closing context
  */
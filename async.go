@@ -0,0 +1,389 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	stdcontext "context"
+	"os"
+	"reflect"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Context created in background by Async()
+ */
+
+type AsyncContext interface {
+	Context
+
+	/**
+		Closed when creation of context completes, successfully or not
+	 */
+	Ready() <-chan struct{}
+
+	/**
+		Error of creation after Ready() is closed, nil before
+	 */
+	CreateErr() error
+}
+
+type asyncContext struct {
+	ready chan struct{}
+	ctx   Context
+	err   error
+
+	/**
+		Closed when the created context is done or creation fails
+	 */
+	done chan struct{}
+}
+
+/**
+	Create context in background and return immediately, for example to serve health checks while beans are initializing.
+	Methods of the returned context except Ready(), CreateErr(), Done() and Err() block until creation completes.
+	If creation fails, methods are served by an empty context in PhaseFailed and Done() is closed.
+
+	Example:
+		ctx := context.Async(beans...)
+		go serveHealth(ctx)
+		<-ctx.Ready()
+		if err := ctx.CreateErr(); err != nil {
+			log.Fatal(err)
+		}
+ */
+func Async(scan ...interface{}) AsyncContext {
+	return AsyncWithOptions(scan)
+}
+
+/**
+	Create context in background with options, see Async()
+ */
+func AsyncWithOptions(scan []interface{}, options ...Option) AsyncContext {
+	t := &asyncContext{
+		ready: make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go func() {
+		ctx, err := CreateWithOptions(scan, options...)
+		if err != nil {
+			if ctx == nil {
+				ctx, _ = Create()
+			}
+			if c, ok := ctx.(*context); ok {
+				c.setPhase(PhaseFailed)
+			}
+			close(t.done)
+		} else if done := ctx.Done(); done != nil {
+			go func() {
+				<-done
+				close(t.done)
+			}()
+		}
+		t.ctx, t.err = ctx, err
+		close(t.ready)
+	}()
+	return t
+}
+
+func (t *asyncContext) Ready() <-chan struct{} {
+	return t.ready
+}
+
+func (t *asyncContext) CreateErr() error {
+	select {
+	case <-t.ready:
+		return t.err
+	default:
+		return nil
+	}
+}
+
+func (t *asyncContext) wait() Context {
+	<-t.ready
+	return t.ctx
+}
+
+func (t *asyncContext) Deadline() (deadline time.Time, ok bool) {
+	return t.wait().Deadline()
+}
+
+func (t *asyncContext) Done() <-chan struct{} {
+	return t.done
+}
+
+/**
+	Returns context.Canceled after Done() is closed, nil before
+ */
+func (t *asyncContext) Err() error {
+	select {
+	case <-t.done:
+		return stdcontext.Canceled
+	default:
+		return nil
+	}
+}
+
+func (t *asyncContext) Value(key interface{}) interface{} {
+	return t.wait().Value(key)
+}
+
+func (t *asyncContext) Close() error {
+	ctx := t.wait()
+	err := ctx.Close()
+	if ctx.Done() != nil {
+		<-t.done
+	}
+	return err
+}
+
+func (t *asyncContext) AsyncClose() <-chan error {
+	return t.wait().AsyncClose()
+}
+
+func (t *asyncContext) NotifyOnClose(ch chan<- struct{}) {
+	t.wait().NotifyOnClose(ch)
+}
+
+func (t *asyncContext) RemoveOnClose(ch chan<- struct{}) {
+	t.wait().RemoveOnClose(ch)
+}
+
+func (t *asyncContext) GracefulClose(signals <-chan os.Signal, timeout time.Duration) error {
+	return t.wait().GracefulClose(signals, timeout)
+}
+
+func (t *asyncContext) Wait() error {
+	return t.wait().Wait()
+}
+
+func (t *asyncContext) Release(obj interface{}) error {
+	return t.wait().Release(obj)
+}
+
+func (t *asyncContext) Drain() error {
+	return t.wait().Drain()
+}
+
+func (t *asyncContext) DrainWithTimeout(d time.Duration) error {
+	return t.wait().DrainWithTimeout(d)
+}
+
+func (t *asyncContext) Freeze() {
+	t.wait().Freeze()
+}
+
+func (t *asyncContext) IsFrozen() bool {
+	return t.wait().IsFrozen()
+}
+
+func (t *asyncContext) Refresh() error {
+	return t.wait().Refresh()
+}
+
+func (t *asyncContext) Core() []reflect.Type {
+	return t.wait().Core()
+}
+
+func (t *asyncContext) BeanCount() int {
+	return t.wait().BeanCount()
+}
+
+func (t *asyncContext) LocalBeanCount() int {
+	return t.wait().LocalBeanCount()
+}
+
+func (t *asyncContext) RequireExactly(types ...reflect.Type) error {
+	return t.wait().RequireExactly(types...)
+}
+
+func (t *asyncContext) OrderedCore() []reflect.Type {
+	return t.wait().OrderedCore()
+}
+
+func (t *asyncContext) GroupBy(classifier func(reflect.Type) string) map[string][]interface{} {
+	return t.wait().GroupBy(classifier)
+}
+
+func (t *asyncContext) Bean(typ reflect.Type) (bean interface{}, ok bool) {
+	return t.wait().Bean(typ)
+}
+
+func (t *asyncContext) MustBean(typ reflect.Type) interface{} {
+	return t.wait().MustBean(typ)
+}
+
+func (t *asyncContext) TryBean(typ reflect.Type) (interface{}, error) {
+	return t.wait().TryBean(typ)
+}
+
+func (t *asyncContext) Resolve(typ reflect.Type) (resolved reflect.Type, via string, err error) {
+	return t.wait().Resolve(typ)
+}
+
+func (t *asyncContext) BeanFor(instance interface{}) (reflect.Type, bool) {
+	return t.wait().BeanFor(instance)
+}
+
+func (t *asyncContext) Lookup(iface string) BeanSlice {
+	return t.wait().Lookup(iface)
+}
+
+func (t *asyncContext) MustLookup(iface string) BeanSlice {
+	return t.wait().MustLookup(iface)
+}
+
+func (t *asyncContext) SortedLookup(iface string, less func(a, b interface{}) bool) BeanSlice {
+	return t.wait().SortedLookup(iface, less)
+}
+
+func (t *asyncContext) FindAll(ifaceType reflect.Type) BeanSlice {
+	return t.wait().FindAll(ifaceType)
+}
+
+func (t *asyncContext) BeanTypesImplementing(ifaceType reflect.Type) []reflect.Type {
+	return t.wait().BeanTypesImplementing(ifaceType)
+}
+
+func (t *asyncContext) BeanNames() []string {
+	return t.wait().BeanNames()
+}
+
+func (t *asyncContext) LookupAllNames() map[string][]interface{} {
+	return t.wait().LookupAllNames()
+}
+
+func (t *asyncContext) Debug() bool {
+	return t.wait().Debug()
+}
+
+func (t *asyncContext) Trace() Trace {
+	return t.wait().Trace()
+}
+
+func (t *asyncContext) Provide(beans ...interface{}) error {
+	return t.wait().Provide(beans...)
+}
+
+func (t *asyncContext) Inject(obj interface{}) (injected []string, err error) {
+	return t.wait().Inject(obj)
+}
+
+func (t *asyncContext) Inspect(obj interface{}) (InjectionReport, error) {
+	return t.wait().Inspect(obj)
+}
+
+func (t *asyncContext) Warmup(classes ...reflect.Type) error {
+	return t.wait().Warmup(classes...)
+}
+
+func (t *asyncContext) Mock(typ reflect.Type, obj interface{}) error {
+	return t.wait().Mock(typ, obj)
+}
+
+func (t *asyncContext) AtomicReplace(expected, replacement interface{}) (bool, error) {
+	return t.wait().AtomicReplace(expected, replacement)
+}
+
+func (t *asyncContext) RecreateBean(typ reflect.Type) error {
+	return t.wait().RecreateBean(typ)
+}
+
+func (t *asyncContext) Snapshot() ContextSnapshot {
+	return t.wait().Snapshot()
+}
+
+func (t *asyncContext) Restore(s ContextSnapshot) error {
+	return t.wait().Restore(s)
+}
+
+func (t *asyncContext) ExplainAmbiguity(ifaceType reflect.Type) string {
+	return t.wait().ExplainAmbiguity(ifaceType)
+}
+
+func (t *asyncContext) Bindings() []Binding {
+	return t.wait().Bindings()
+}
+
+func (t *asyncContext) Phase() Phase {
+	return t.wait().Phase()
+}
+
+func (t *asyncContext) Diff(other Context) WiringDiff {
+	return t.wait().Diff(other)
+}
+
+func (t *asyncContext) Stats() Stats {
+	return t.wait().Stats()
+}
+
+func (t *asyncContext) Health() HealthReport {
+	return t.wait().Health()
+}
+
+func (t *asyncContext) Observe(observer ContextObserver) {
+	t.wait().Observe(observer)
+}
+
+func (t *asyncContext) Graph() Graph {
+	return t.wait().Graph()
+}
+
+func (t *asyncContext) InjectionGraph() map[reflect.Type][]reflect.Type {
+	return t.wait().InjectionGraph()
+}
+
+func (t *asyncContext) Scan() []ScanResult {
+	return t.wait().Scan()
+}
+
+func (t *asyncContext) GetBeanDefinition(typ reflect.Type) (BeanDefinition, bool) {
+	return t.wait().GetBeanDefinition(typ)
+}
+
+func (t *asyncContext) BeanMetadata(typ reflect.Type) map[string]string {
+	return t.wait().BeanMetadata(typ)
+}
+
+func (t *asyncContext) FindByMetadata(key, value string) []interface{} {
+	return t.wait().FindByMetadata(key, value)
+}
+
+func (t *asyncContext) ExtractBeans(pkg string) []interface{} {
+	return t.wait().ExtractBeans(pkg)
+}
+
+func (t *asyncContext) Fork(scan ...interface{}) (Context, error) {
+	return t.wait().Fork(scan...)
+}
+
+func (t *asyncContext) Clone() Context {
+	return t.wait().Clone()
+}
+
+func (t *asyncContext) With(key, value interface{}) Context {
+	return t.wait().With(key, value)
+}
+
+func (t *asyncContext) Unwrap() Context {
+	return t.wait().Unwrap()
+}
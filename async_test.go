@@ -0,0 +1,109 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	stdcontext "context"
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestAsync(t *testing.T) {
+
+	context.Verbose = false
+
+	storage := &configStorage{}
+	ctx := context.Async(storage, &configServiceImpl{}, &slowInitBean{delay: 50 * time.Millisecond})
+
+	select {
+	case <-ctx.Ready():
+		t.Fatal("context must not be ready before PostConstruct() returns")
+	default:
+		require.Nil(t, ctx.CreateErr())
+		require.Nil(t, ctx.Err())
+	}
+
+	select {
+	case <-ctx.Ready():
+	case <-time.After(time.Second):
+		t.Fatal("context is not ready")
+	}
+	require.Nil(t, ctx.CreateErr())
+	require.Nil(t, ctx.Err())
+	require.Equal(t, storage, ctx.MustBean(StorageClass))
+	require.NotNil(t, ctx.MustBean(ConfigServiceClass))
+	require.Equal(t, context.PhaseReady, ctx.Phase())
+
+	require.Nil(t, ctx.Close())
+	<-ctx.Done()
+	require.Equal(t, stdcontext.Canceled, ctx.Err())
+
+}
+
+func TestAsyncBlocksBean(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx := context.Async(&configStorage{}, &slowInitBean{delay: 20 * time.Millisecond})
+	_, ok := ctx.Bean(StorageClass)
+	require.True(t, ok)
+
+	ctx = context.Async(&configServiceImpl{})
+	<-ctx.Ready()
+	require.NotNil(t, ctx.CreateErr())
+	require.Equal(t, stdcontext.Canceled, ctx.Err())
+	require.Equal(t, context.PhaseFailed, ctx.Phase())
+	_, ok = ctx.Bean(StorageClass)
+	require.False(t, ok)
+
+}
+
+func TestAsyncBaseContext(t *testing.T) {
+
+	context.Verbose = false
+
+	base, cancel := stdcontext.WithCancel(stdcontext.Background())
+	ctx := context.AsyncWithOptions([]interface{}{&configStorage{}}, context.WithBaseContext(base))
+
+	select {
+	case <-ctx.Done():
+		t.Fatal("context is done before cancel of the base context")
+	default:
+		require.Nil(t, ctx.Err())
+	}
+
+	<-ctx.Ready()
+	require.Nil(t, ctx.CreateErr())
+
+	cancel()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("context is not done after cancel of the base context")
+	}
+	require.Equal(t, stdcontext.Canceled, ctx.Err())
+	require.Equal(t, stdcontext.Canceled, ctx.Wait())
+
+}
@@ -21,7 +21,10 @@ package context
 import (
 	"fmt"
 	"github.com/pkg/errors"
+	"log"
 	"reflect"
+	"strings"
+	"unsafe"
 )
 
 /**
@@ -47,6 +50,18 @@ type injectionDef struct {
 	Type of the field that is going to be injected
 	*/
 	fieldType reflect.Type
+	/**
+	Tag of the field that is going to be injected
+	*/
+	fieldTag  reflect.StructTag
+	/**
+	Field stays nil if implementation not found, tag `inject:"optional"`
+	*/
+	optional  bool
+	/**
+	Name of the bean to inject, tag `inject:"name"`
+	*/
+	qualifier string
 
 }
 
@@ -61,6 +76,11 @@ type injection struct {
 	 */
 	injectionDef  *injectionDef
 
+	/**
+		Bean that owns the struct
+	 */
+	owner     *bean
+
 }
 
 
@@ -82,6 +102,26 @@ type beanDef struct {
 		Fields that are going to be injected
 	 */
 	fields        []*injectionDef
+
+	/**
+		Fields that are going to be set from property sources
+	 */
+	properties    []*propertyDef
+
+	/**
+		Fields that are going to be set from environment variables
+	 */
+	envs          []*envInjection
+
+	/**
+		Initialization priority from 'order' tag, for example `_ struct{} \`order:"100"\``
+	 */
+	order         int
+
+	/**
+		Error of parsing the 'order' tag, reported on creation of context only if WithStartupOrderAnnotation is set
+	 */
+	invalidOrder  error
 }
 
 type bean struct {
@@ -89,6 +129,10 @@ type bean struct {
 		Instance to the bean
 	 */
 	obj      interface{}
+	/**
+		Instance returned by the context, the result of mappers applied to obj
+	 */
+	exposed  interface{}
 	/**
 		Reflect instance to the pointer or interface of the bean
 	 */
@@ -97,6 +141,22 @@ type bean struct {
 		Bean description
 	 */
 	beanDef  *beanDef
+	/**
+		Beans injected in to this bean on creation of context
+	 */
+	dependencies []*bean
+	/**
+		Free-form metadata of the bean from MetadataProvider
+	 */
+	metadata     map[string]string
+	/**
+		Position of the bean in the scan list with expanded modules, zero for beans not in core
+	 */
+	position     int
+	/**
+		Name of the bean given on creation of context by NewContextFromMap, empty if not named
+	 */
+	name         string
 }
 
 
@@ -104,6 +164,9 @@ type bean struct {
 	Check if bean definition can implement interface type
  */
 func (t *beanDef) implements(ifaceType reflect.Type) bool {
+	if ifaceType.Kind() != reflect.Interface {
+		return false
+	}
 	for _, ni := range t.notImplements {
 		if ni == ifaceType {
 			return false
@@ -115,15 +178,95 @@ func (t *beanDef) implements(ifaceType reflect.Type) bool {
 /**
 	Inject value in to the field by using reflection
  */
-func (t *injection) inject(impl *bean) error {
-	return t.injectionDef.inject(&t.value, impl)
+func (t *injection) inject(impl *bean, unexported bool) error {
+	if err := t.injectionDef.validate(impl); err != nil {
+		return err
+	}
+	if err := t.injectionDef.inject(&t.value, impl, unexported); err != nil {
+		return err
+	}
+	t.owner.addDependency(impl)
+	return nil
+}
+
+/**
+	Parse value of the inject tag, comma separated name of the bean and 'optional' flag
+ */
+func (t *injectionDef) parseTag(expr string) error {
+	for _, part := range strings.Split(expr, ",") {
+		switch part = strings.TrimSpace(part); part {
+		case "optional":
+			t.optional = true
+		case "":
+			return errors.Errorf("invalid inject tag '%s'", expr)
+		default:
+			if t.qualifier != "" {
+				return errors.Errorf("invalid inject tag '%s'", expr)
+			}
+			t.qualifier = part
+		}
+	}
+	return nil
+}
+
+func (t *bean) addDependency(impl *bean) {
+	for _, d := range t.dependencies {
+		if d == impl {
+			return
+		}
+	}
+	t.dependencies = append(t.dependencies, impl)
 }
 
 
-func (t *injectionDef) inject(value *reflect.Value, impl *bean) error {
+/**
+	Check that the resolved bean satisfies the type of the field before injection
+ */
+func (t *injectionDef) validate(impl *bean) error {
+	classPtr := impl.beanDef.classPtr
+	switch t.fieldType.Kind() {
+	case reflect.Interface:
+		if !classPtr.Implements(t.fieldType) {
+			return errors.Errorf("bean '%v' does not implement interface '%v' of field '%s' in class '%v'", classPtr, t.fieldType, t.fieldName, t.class)
+		}
+	case reflect.Ptr:
+		if classPtr != t.fieldType {
+			return errors.Errorf("bean '%v' does not match type '%v' of field '%s' in class '%v'", classPtr, t.fieldType, t.fieldName, t.class)
+		}
+	}
+	return nil
+}
+
+/**
+	Unexported fields are set through unsafe pointer only if enabled by WithUnexportedFieldInjection
+ */
+func (t *injectionDef) inject(value *reflect.Value, impl *bean, unexported bool) error {
+	if !impl.valuePtr.Type().AssignableTo(t.fieldType) {
+		return errors.Errorf("bean '%v' is not assignable to field '%s' with type '%v' in class '%v'", impl.beanDef.classPtr, t.fieldName, t.fieldType, t.class)
+	}
+	return t.set(value, impl.valuePtr, unexported)
+}
+
+/**
+	Inject the instance returned by the context on runtime, that could be mapped or decorated
+ */
+func (t *injectionDef) injectExposed(value *reflect.Value, impl *bean, unexported bool) error {
+	exposed := reflect.ValueOf(impl.exposed)
+	if !exposed.Type().AssignableTo(t.fieldType) {
+		return errors.Errorf("bean '%v' is not assignable to field '%s' with type '%v' in class '%v'", exposed.Type(), t.fieldName, t.fieldType, t.class)
+	}
+	return t.set(value, exposed, unexported)
+}
+
+func (t *injectionDef) set(value *reflect.Value, v reflect.Value, unexported bool) error {
 	field := value.Field(t.fieldNum)
 	if field.CanSet() {
-		field.Set(impl.valuePtr)
+		field.Set(v)
+		return nil
+	} else if unexported && field.CanAddr() {
+		log.Printf("warning: inject '%v' in to unexported field '%s' in class '%v'\n", v.Type(), t.fieldName, t.class)
+		field = reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+		field.Set(v)
 		return nil
 	} else {
 		return errors.Errorf("field '%s' in class '%v' is not public", t.fieldName, t.class)
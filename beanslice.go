@@ -0,0 +1,97 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"reflect"
+	"sort"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Ordered list of beans returned by FindAll() and Lookup().
+	Could be used as []interface{}, methods are helpers for deterministic assertions in tests.
+
+	Example:
+		require.True(t, ctx.FindAll(StorageClass).Contains(reflect.TypeOf(&storageImpl{})))
+ */
+type BeanSlice []interface{}
+
+func (t BeanSlice) Len() int {
+	return len(t)
+}
+
+func (t BeanSlice) At(i int) interface{} {
+	return t[i]
+}
+
+/**
+	Classes of the beans in the same order, nil for nil entries
+ */
+func (t BeanSlice) Types() []reflect.Type {
+	list := make([]reflect.Type, len(t))
+	for i, b := range t {
+		list[i] = reflect.TypeOf(b)
+	}
+	return list
+}
+
+/**
+	Check if the slice has a bean of the class or a bean implementing the interface
+ */
+func (t BeanSlice) Contains(typ reflect.Type) bool {
+	for _, b := range t {
+		if b == nil {
+			continue
+		}
+		classPtr := reflect.TypeOf(b)
+		if classPtr == typ || typ.Kind() == reflect.Interface && classPtr.Implements(typ) {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+	Sorted copy of the slice, the original slice is not changed
+ */
+func (t BeanSlice) Sort(less func(a, b interface{}) bool) BeanSlice {
+	list := make(BeanSlice, len(t))
+	copy(list, t)
+	sort.SliceStable(list, func(i, j int) bool {
+		return less(list[i], list[j])
+	})
+	return list
+}
+
+/**
+	Copy of the slice without nil entries
+ */
+func (t BeanSlice) FilterNil() BeanSlice {
+	var list BeanSlice
+	for _, b := range t {
+		if b != nil {
+			list = append(list, b)
+		}
+	}
+	return list
+}
@@ -0,0 +1,70 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestBeanSlice(t *testing.T) {
+
+	context.Verbose = false
+
+	storage := &configStorage{}
+	ctx, err := context.Create(
+		&lruCache{},
+		storage,
+		&diskCache{},
+		context.Interface((*Storage)(nil)),
+	)
+	require.Nil(t, err)
+
+	storages := ctx.FindAll(StorageClass)
+	require.Equal(t, 1, storages.Len())
+	require.Equal(t, storage, storages.At(0))
+	require.True(t, storages.Contains(StorageClass))
+	require.True(t, storages.Contains(reflect.TypeOf(storage)))
+	require.False(t, storages.Contains(UserServiceClass))
+
+	require.True(t, ctx.Lookup("context_test.Storage").Contains(StorageClass))
+
+	caches := ctx.FindAll(reflect.TypeOf((*optionalCache)(nil)).Elem())
+	require.Equal(t, []reflect.Type{reflect.TypeOf(&lruCache{}), reflect.TypeOf(&diskCache{})}, caches.Types())
+
+	sorted := caches.Sort(func(a, b interface{}) bool {
+		return a.(optionalCache).Get("") < b.(optionalCache).Get("")
+	})
+	require.Equal(t, []reflect.Type{reflect.TypeOf(&diskCache{}), reflect.TypeOf(&lruCache{})}, sorted.Types())
+	require.Equal(t, reflect.TypeOf(&lruCache{}), reflect.TypeOf(caches.At(0)))
+
+	withNil := context.BeanSlice{nil, storage, nil}
+	require.Equal(t, context.BeanSlice{storage}, withNil.FilterNil())
+	require.Equal(t, []reflect.Type{nil, reflect.TypeOf(storage), nil}, withNil.Types())
+
+	var plain []interface{} = ctx.FindAll(StorageClass)
+	require.Equal(t, 1, len(plain))
+
+}
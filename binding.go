@@ -0,0 +1,167 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+/**
+@author Alex Shvid
+*/
+
+const (
+	ResolutionDirectPointer   = "direct-pointer-match"
+	ResolutionInterfaceSearch = "interface-search"
+	ResolutionNamedLookup     = "named-lookup"
+)
+
+/**
+	Information about how the type is wired in the context
+ */
+
+type Binding struct {
+
+	/**
+		Requested type, that is a pointer to the structure or interface
+	 */
+	InterfaceType reflect.Type
+
+	/**
+		Class of the bean that is registered under InterfaceType
+	 */
+	ImplType      reflect.Type
+
+	/**
+		Algorithm that found the implementation
+	 */
+	Resolution    string
+
+	/**
+		Struct tag key-value pairs of the inject field that requested the type, empty if requested on runtime
+	 */
+	Tags          map[string]string
+}
+
+func (t *context) Bindings() []Binding {
+	var list []Binding
+	for ifaceType, b := range t.wiring() {
+		resolution := ResolutionInterfaceSearch
+		if ifaceType.Kind() == reflect.Ptr {
+			resolution = ResolutionDirectPointer
+		}
+		list = append(list, Binding{
+			InterfaceType: ifaceType,
+			ImplType:      b.beanDef.classPtr,
+			Resolution:    resolution,
+		})
+	}
+	for i := range list {
+		list[i].Tags = t.injectionTags(list[i].InterfaceType)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].InterfaceType.String() < list[j].InterfaceType.String()
+	})
+	return list
+}
+
+/**
+	Types bound in the context on creation or registered on runtime, and interfaces of inject fields searched in core.
+	Types cached on lookup are not included, nothing is registered in the context.
+ */
+func (t *context) wiring() map[reflect.Type]*bean {
+	core := t.coreBeans()
+	bound := t.registry.wiredTypes()
+	for _, b := range core {
+		for _, f := range b.beanDef.fields {
+			if _, ok := bound[f.fieldType]; ok || f.fieldType.Kind() != reflect.Interface {
+				continue
+			}
+			if service, err := searchByInterface(f.fieldType, core); err == nil {
+				bound[f.fieldType] = service
+			}
+		}
+	}
+	return bound
+}
+
+/**
+	Find tags of the first inject field in core that requires the type
+ */
+func (t *context) injectionTags(fieldType reflect.Type) map[string]string {
+	var found *injectionDef
+	for _, b := range t.coreBeans() {
+		for _, f := range b.beanDef.fields {
+			if f.fieldType == fieldType && (found == nil || f.String() < found.String()) {
+				found = f
+			}
+		}
+	}
+	if found == nil {
+		return map[string]string{}
+	}
+	return parseTags(found.fieldTag)
+}
+
+/**
+	Parse struct tag in to key-value pairs, keys without values like `inject` have empty values
+ */
+func parseTags(tag reflect.StructTag) map[string]string {
+	tags := make(map[string]string)
+	s := string(tag)
+	for {
+		s = strings.TrimLeft(s, " \t")
+		if s == "" {
+			break
+		}
+		i := 0
+		for i < len(s) && s[i] != ' ' && s[i] != ':' && s[i] != '\t' {
+			i++
+		}
+		if i == 0 {
+			// malformed tag
+			s = s[1:]
+			continue
+		}
+		key := s[:i]
+		s = s[i:]
+		if strings.HasPrefix(s, ":\"") {
+			if value, ok := tag.Lookup(key); ok {
+				tags[key] = value
+			}
+			// skip quoted value
+			j := 2
+			for j < len(s) && s[j] != '"' {
+				if s[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j < len(s) {
+				j++
+			}
+			s = s[j:]
+		} else {
+			tags[key] = ""
+		}
+	}
+	return tags
+}
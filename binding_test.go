@@ -0,0 +1,73 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"log"
+	"os"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestBindings(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+
+	ctx, err := context.Create(
+		logger,
+		&storageImpl{},
+		&configServiceImpl{},
+		&userServiceImpl{},
+		&struct{ UserService `inject` }{},
+	)
+	require.Nil(t, err)
+
+	bindings := ctx.Bindings()
+	require.Equal(t, 4, len(bindings))
+
+	expected := []struct {
+		iface      reflect.Type
+		impl       reflect.Type
+		resolution string
+	}{
+		{reflect.TypeOf(logger), reflect.TypeOf(logger), context.ResolutionDirectPointer},
+		{ConfigServiceClass, reflect.TypeOf(&configServiceImpl{}), context.ResolutionInterfaceSearch},
+		{StorageClass, reflect.TypeOf(&storageImpl{}), context.ResolutionInterfaceSearch},
+		{UserServiceClass, reflect.TypeOf(&userServiceImpl{}), context.ResolutionInterfaceSearch},
+	}
+	for i, e := range expected {
+		require.Equal(t, e.iface, bindings[i].InterfaceType)
+		require.Equal(t, e.impl, bindings[i].ImplType)
+		require.Equal(t, e.resolution, bindings[i].Resolution)
+		require.Equal(t, map[string]string{"inject": ""}, bindings[i].Tags)
+	}
+
+	// lookups do not change bindings
+	require.NotNil(t, ctx.MustBean(reflect.TypeOf(&storageImpl{})))
+	require.NotNil(t, ctx.MustBean(reflect.TypeOf(&userServiceImpl{})))
+	require.Equal(t, bindings, ctx.Bindings())
+
+}
@@ -0,0 +1,67 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	stdcontext "context"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Create context with the cancel function that closes it, modeled after errgroup.WithContext.
+	The error passed to cancel is returned by Wait(), only the first call of cancel closes the context and sets the error.
+
+	Example:
+		ctx, cancel, err := context.CreateContext(beans...)
+		go supervise(func(err error) { cancel(err) })
+		if err := ctx.Wait(); err != nil {
+			log.Printf("context stopped: %v", err)
+		}
+ */
+func CreateContext(scan ...interface{}) (ctx Context, cancel func(error), err error) {
+	c, err := create(scan, newContextConfig(nil), stdcontext.Background())
+	if err != nil {
+		ctx, err = toContext(c, err)
+		return ctx, nil, err
+	}
+	return c, c.cancel, nil
+}
+
+func (t *context) cancel(err error) {
+	t.causeMu.Lock()
+	select {
+	case <-t.closed:
+	default:
+		if t.cause == nil {
+			t.cause = err
+		}
+	}
+	t.causeMu.Unlock()
+	t.Close()
+}
+
+func (t *context) Wait() error {
+	<-t.closed
+	t.causeMu.Lock()
+	defer t.causeMu.Unlock()
+	return t.cause
+}
@@ -0,0 +1,87 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestCreateContext(t *testing.T) {
+
+	context.Verbose = false
+	resource := &parentResource{}
+
+	ctx, cancel, err := context.CreateContext(resource, &configStorage{})
+	require.Nil(t, err)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel(errors.New("shutdown requested"))
+	}()
+
+	err = ctx.Wait()
+	require.NotNil(t, err)
+	require.Equal(t, "shutdown requested", err.Error())
+	require.True(t, resource.destroyed)
+	require.Equal(t, context.PhaseClosed, ctx.Phase())
+
+	cancel(errors.New("second"))
+	require.Equal(t, "shutdown requested", ctx.Wait().Error())
+
+	_, cancel, err = context.CreateContext(&userServiceImpl{})
+	require.NotNil(t, err)
+	require.Nil(t, cancel)
+
+}
+
+func TestCancelTwice(t *testing.T) {
+
+	context.Verbose = false
+	resource := &disposableInitBean{}
+
+	ctx, cancel, err := context.CreateContext(resource)
+	require.Nil(t, err)
+
+	cancel(errors.New("first"))
+	cancel(errors.New("second"))
+	require.Nil(t, ctx.Close())
+	require.Equal(t, int32(1), resource.destroyed)
+	require.Equal(t, "first", ctx.Wait().Error())
+
+}
+
+func TestWaitClose(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create(&configStorage{})
+	require.Nil(t, err)
+
+	require.Nil(t, ctx.Close())
+	require.Nil(t, ctx.Wait())
+
+}
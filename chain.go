@@ -0,0 +1,114 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"reflect"
+	"strings"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Field of the class through which the bean was injected on runtime
+ */
+type injectionStep struct {
+	class     reflect.Type
+	fieldName string
+}
+
+/**
+	Check that the bean injected on runtime has all inject fields set.
+	Beans in core are wired on creation of context, but instances returned by mappers
+	or taken from parent contexts could miss dependencies that were not there at that time.
+ */
+func (t *context) checkInjected(impl *bean, path []injectionStep) error {
+	if impl.exposed == impl.obj && t.wired(impl) {
+		return nil
+	}
+	return t.checkFields(impl.exposed, path, make(map[interface{}]bool))
+}
+
+/**
+	Check if the bean is in core of this context or one of the parent contexts
+ */
+func (t *context) wired(impl *bean) bool {
+	for ctx := t; ctx != nil; {
+		if ctx.coreBeans()[impl.beanDef.classPtr] == impl {
+			return true
+		}
+		parent, ok := ctx.parent.(*context)
+		if !ok {
+			return false
+		}
+		ctx = parent
+	}
+	return false
+}
+
+func (t *context) checkFields(obj interface{}, path []injectionStep, visited map[interface{}]bool) error {
+	classPtr := reflect.TypeOf(obj)
+	if classPtr.Kind() != reflect.Ptr || classPtr.Elem().Kind() != reflect.Struct || visited[obj] {
+		return nil
+	}
+	visited[obj] = true
+	bd, err := t.cache(obj, classPtr)
+	if err != nil {
+		return err
+	}
+	value := reflect.ValueOf(obj).Elem()
+	for _, inject := range bd.fields {
+		field := value.Field(inject.fieldNum)
+		if field.IsNil() {
+			reason := "no bean found"
+			if _, ok := t.getBean(inject.fieldType); ok {
+				reason = "not injected"
+			} else if inject.optional {
+				continue
+			}
+			return errors.New(injectionChain(inject, classPtr, path, reason))
+		}
+		if field.CanInterface() && field.Kind() != reflect.Func {
+			next := append(append([]injectionStep(nil), path...), injectionStep{classPtr, inject.fieldName})
+			if err := t.checkFields(field.Interface(), next, visited); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+/**
+	Error message with the chain of injections, for example
+	"field 'Audit' (type app.AuditLog) in *app.auditedService: injected in to *app.handler via field 'UserService': no bean found"
+ */
+func injectionChain(inject *injectionDef, classPtr reflect.Type, path []injectionStep, reason string) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "field '%s' (type %v) in %v", inject.fieldName, inject.fieldType, classPtr)
+	for i := len(path) - 1; i >= 0; i-- {
+		fmt.Fprintf(&out, ": injected in to %v via field '%s'", path[i].class, path[i].fieldName)
+	}
+	out.WriteString(": ")
+	out.WriteString(reason)
+	return out.String()
+}
@@ -0,0 +1,86 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"log"
+	"os"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type AuditLog interface {
+	Audit(event string)
+}
+
+type auditedUserService struct {
+	UserService `inject`
+	Audit       AuditLog `inject`
+}
+
+type auditMapper struct {
+}
+
+func (t auditMapper) Map(obj interface{}) interface{} {
+	if s, ok := obj.(*userServiceImpl); ok {
+		return &auditedUserService{UserService: s}
+	}
+	return obj
+}
+
+func TestInjectionChain(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+
+	ctx, err := context.CreateWithOptions([]interface{}{
+		logger,
+		&storageImpl{},
+		&configServiceImpl{},
+		&userServiceImpl{},
+		context.Interface((*UserService)(nil)),
+	}, context.WithMapper(auditMapper{}))
+	require.Nil(t, err)
+
+	_, err = ctx.Inject(&requestScope{})
+	require.NotNil(t, err)
+	require.Equal(t, "field 'Audit' (type context_test.AuditLog) in *context_test.auditedUserService: injected in to *context_test.requestScope via field 'UserService': no bean found", err.Error())
+
+}
+
+func TestInjectionChainWired(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+
+	root, err := context.Create(logger, &storageImpl{}, &configServiceImpl{}, &userServiceImpl{})
+	require.Nil(t, err)
+
+	child, err := root.Fork()
+	require.Nil(t, err)
+
+	_, err = child.Inject(&requestScope{})
+	require.Nil(t, err)
+
+}
@@ -0,0 +1,839 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	stdcontext "context"
+	"fmt"
+	"github.com/pkg/errors"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Returned by the composite context of Chain() for operations that modify a single context
+ */
+var ErrNotSupported = errors.New("operation is not supported by the chain of contexts")
+
+/**
+	Composite of peer contexts searched in order, the first context has priority.
+	Queries are served by all contexts, mutating operations that could not be applied to all contexts return ErrNotSupported.
+ */
+type chainedContext struct {
+	contexts []Context
+	metadata map[interface{}]interface{}
+	*chainState
+}
+
+/**
+	State of the chain shared with copies created by With()
+ */
+type chainState struct {
+	done          chan struct{}
+	closeOnce     sync.Once
+	closeErr      error
+	asyncClose    chan error
+	asyncOnce     sync.Once
+	closeNotify   []chan<- struct{}
+	closeNotifyMu sync.Mutex
+
+	/**
+		Objects created by LookupOrCreate(), key is reflect.Type, value is *lazyObject
+	 */
+	lazy sync.Map
+}
+
+/**
+	Create composite context that searches beans in all contexts in the order of arguments.
+	Unlike Fork() the contexts are peers, none of them is parent of another one.
+	Close() closes all contexts. Panics if no contexts given.
+	Provide(), Mock(), RecreateBean() and Restore() return ErrNotSupported, Freeze() and AtomicReplace() apply to all contexts.
+	Inject() searches beans in all contexts and uses property sources, converters and hooks of the first context.
+
+	Example:
+		ctx := context.Chain(overridesCtx, appCtx)
+		storage := ctx.MustBean(StorageClass)
+ */
+func Chain(ctxs ...Context) Context {
+	if len(ctxs) == 0 {
+		panic("chain requires at least one context")
+	}
+	return &chainedContext{
+		contexts:   ctxs,
+		chainState: &chainState{done: make(chan struct{})},
+	}
+}
+
+func (t *chainedContext) Bean(typ reflect.Type) (interface{}, bool) {
+	for _, ctx := range t.contexts {
+		if b, ok := ctx.Bean(typ); ok {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+func (t *chainedContext) MustBean(typ reflect.Type) interface{} {
+	if bean, ok := t.Bean(typ); ok {
+		return bean
+	} else {
+		panic(fmt.Sprintf("bean not found %v", typ))
+	}
+}
+
+/**
+	Returns SentinelError if the bean is not found and any of the contexts is creating
+ */
+func (t *chainedContext) TryBean(typ reflect.Type) (interface{}, error) {
+	var err error
+	for _, ctx := range t.contexts {
+		b, e := ctx.TryBean(typ)
+		if e == nil {
+			return b, nil
+		}
+		if err == nil || IsSentinel(e) {
+			err = e
+		}
+	}
+	return nil, err
+}
+
+func (t *chainedContext) Lookup(iface string) BeanSlice {
+	var list BeanSlice
+	for _, ctx := range t.contexts {
+		list = append(list, ctx.Lookup(iface)...)
+	}
+	return list
+}
+
+func (t *chainedContext) SortedLookup(iface string, less func(a, b interface{}) bool) BeanSlice {
+	return t.Lookup(iface).Sort(less)
+}
+
+func (t *chainedContext) MustLookup(iface string) BeanSlice {
+	return mustLookup(t, iface)
+}
+
+func (t *chainedContext) FindAll(ifaceType reflect.Type) BeanSlice {
+	var list BeanSlice
+	for _, ctx := range t.contexts {
+		list = append(list, ctx.FindAll(ifaceType)...)
+	}
+	return list
+}
+
+func (t *chainedContext) Core() []reflect.Type {
+	var list []reflect.Type
+	visited := make(map[reflect.Type]bool)
+	for _, ctx := range t.contexts {
+		for _, typ := range ctx.Core() {
+			if !visited[typ] {
+				visited[typ] = true
+				list = append(list, typ)
+			}
+		}
+	}
+	return list
+}
+
+func (t *chainedContext) BeanCount() int {
+	return len(t.Core())
+}
+
+func (t *chainedContext) LocalBeanCount() int {
+	return len(t.Core())
+}
+
+func (t *chainedContext) BeanNames() []string {
+	var list []string
+	visited := make(map[string]bool)
+	for _, ctx := range t.contexts {
+		for _, name := range ctx.BeanNames() {
+			if !visited[name] {
+				visited[name] = true
+				list = append(list, name)
+			}
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+/**
+	Close all contexts in order and collect all errors, repeated calls return the same result
+ */
+func (t *chainedContext) Close() error {
+	t.closeOnce.Do(func() {
+		var err []error
+		for _, ctx := range t.contexts {
+			if e := ctx.Close(); e != nil {
+				err = append(err, e)
+			}
+		}
+		t.closeErr = multiple(err)
+		close(t.done)
+		t.closeNotifyMu.Lock()
+		list := t.closeNotify
+		t.closeNotify = nil
+		t.closeNotifyMu.Unlock()
+		for _, ch := range list {
+			close(ch)
+		}
+	})
+	return t.closeErr
+}
+
+func (t *chainedContext) AsyncClose() <-chan error {
+	t.asyncOnce.Do(func() {
+		t.asyncClose = make(chan error, 1)
+		go func() {
+			t.asyncClose <- t.Close()
+		}()
+	})
+	return t.asyncClose
+}
+
+/**
+	Channel is closed on Close() of the chain
+ */
+func (t *chainedContext) NotifyOnClose(ch chan<- struct{}) {
+	t.closeNotifyMu.Lock()
+	defer t.closeNotifyMu.Unlock()
+	t.closeNotify = append(t.closeNotify, ch)
+}
+
+func (t *chainedContext) RemoveOnClose(ch chan<- struct{}) {
+	t.closeNotifyMu.Lock()
+	defer t.closeNotifyMu.Unlock()
+	for i, c := range t.closeNotify {
+		if c == ch {
+			t.closeNotify = append(t.closeNotify[:i], t.closeNotify[i+1:]...)
+			return
+		}
+	}
+}
+
+func (t *chainedContext) GracefulClose(signals <-chan os.Signal, timeout time.Duration) error {
+	select {
+	case <-signals:
+	case <-t.Done():
+		return nil
+	}
+	goCtx, cancel := stdcontext.WithTimeout(stdcontext.Background(), timeout)
+	defer cancel()
+	return closeWithContext(goCtx, t)
+}
+
+/**
+	Wait until all contexts are closed and collect their errors
+ */
+func (t *chainedContext) Wait() error {
+	var err []error
+	for _, ctx := range t.contexts {
+		if e := ctx.Wait(); e != nil {
+			err = append(err, e)
+		}
+	}
+	return multiple(err)
+}
+
+/**
+	Destroy the object by the first context and stop tracking it in all contexts
+ */
+func (t *chainedContext) Release(obj interface{}) error {
+	err := t.contexts[0].Release(obj)
+	for _, ctx := range t.contexts[1:] {
+		if c, ok := ctx.(*context); ok {
+			c.release(obj)
+		}
+	}
+	return err
+}
+
+func (t *chainedContext) Drain() error {
+	var err []error
+	for _, ctx := range t.contexts {
+		if e := ctx.Drain(); e != nil {
+			err = append(err, e)
+		}
+	}
+	return multiple(err)
+}
+
+/**
+	Drain all contexts within the total duration
+ */
+func (t *chainedContext) DrainWithTimeout(d time.Duration) error {
+	deadline := time.Now().Add(d)
+	for _, ctx := range t.contexts {
+		if err := ctx.DrainWithTimeout(time.Until(deadline)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *chainedContext) Freeze() {
+	for _, ctx := range t.contexts {
+		ctx.Freeze()
+	}
+}
+
+/**
+	Returns true if any of the contexts is frozen
+ */
+func (t *chainedContext) IsFrozen() bool {
+	for _, ctx := range t.contexts {
+		if ctx.IsFrozen() {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *chainedContext) Refresh() error {
+	var err []error
+	for _, ctx := range t.contexts {
+		if e := ctx.Refresh(); e != nil {
+			err = append(err, e)
+		}
+	}
+	return multiple(err)
+}
+
+func (t *chainedContext) RequireExactly(types ...reflect.Type) error {
+	return requireExactly(t.Core(), types)
+}
+
+func (t *chainedContext) OrderedCore() []reflect.Type {
+	var list []reflect.Type
+	visited := make(map[reflect.Type]bool)
+	for _, ctx := range t.contexts {
+		for _, typ := range ctx.OrderedCore() {
+			if !visited[typ] {
+				visited[typ] = true
+				list = append(list, typ)
+			}
+		}
+	}
+	return list
+}
+
+func (t *chainedContext) GroupBy(classifier func(reflect.Type) string) map[string][]interface{} {
+	groups := make(map[string][]interface{})
+	for _, ctx := range t.contexts {
+		for key, list := range ctx.GroupBy(classifier) {
+			groups[key] = append(groups[key], list...)
+		}
+	}
+	return groups
+}
+
+/**
+	Resolution by the first context that finds the type
+ */
+func (t *chainedContext) Resolve(typ reflect.Type) (resolved reflect.Type, via string, err error) {
+	var firstErr error
+	for _, ctx := range t.contexts {
+		resolved, via, err = ctx.Resolve(typ)
+		if err == nil {
+			return resolved, via, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return nil, "", firstErr
+}
+
+func (t *chainedContext) BeanFor(instance interface{}) (reflect.Type, bool) {
+	for _, ctx := range t.contexts {
+		if typ, ok := ctx.BeanFor(instance); ok {
+			return typ, true
+		}
+	}
+	return nil, false
+}
+
+func (t *chainedContext) BeanTypesImplementing(ifaceType reflect.Type) []reflect.Type {
+	var list []reflect.Type
+	visited := make(map[reflect.Type]bool)
+	for _, ctx := range t.contexts {
+		for _, typ := range ctx.BeanTypesImplementing(ifaceType) {
+			if !visited[typ] {
+				visited[typ] = true
+				list = append(list, typ)
+			}
+		}
+	}
+	sortTypes(list)
+	return list
+}
+
+func (t *chainedContext) LookupAllNames() map[string][]interface{} {
+	names := make(map[string][]interface{})
+	for _, ctx := range t.contexts {
+		for name, list := range ctx.LookupAllNames() {
+			names[name] = append(names[name], list...)
+		}
+	}
+	return names
+}
+
+/**
+	Returns true if any of the contexts captures the trace on failure
+ */
+func (t *chainedContext) Debug() bool {
+	for _, ctx := range t.contexts {
+		if ctx.Debug() {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+	Traces of all contexts in order
+ */
+func (t *chainedContext) Trace() Trace {
+	var list Trace
+	for _, ctx := range t.contexts {
+		list = append(list, ctx.Trace()...)
+	}
+	return list
+}
+
+func (t *chainedContext) Provide(beans ...interface{}) error {
+	return ErrNotSupported
+}
+
+func (t *chainedContext) Inspect(obj interface{}) (InjectionReport, error) {
+	if obj == nil {
+		return InjectionReport{}, errors.New("null obj is are not allowed")
+	}
+	classPtr := reflect.TypeOf(obj)
+	if classPtr.Kind() != reflect.Ptr || classPtr.Elem().Kind() != reflect.Struct {
+		return InjectionReport{}, errors.Errorf("non-pointer instances are not allowed, type %v", classPtr)
+	}
+	b, err := investigate(obj, classPtr)
+	if err != nil {
+		return InjectionReport{}, err
+	}
+	return inspectFields(t, obj, b.beanDef), nil
+}
+
+func (t *chainedContext) Warmup(classes ...reflect.Type) error {
+	var err []error
+	for _, ctx := range t.contexts {
+		if e := ctx.Warmup(classes...); e != nil {
+			err = append(err, e)
+		}
+	}
+	return multiple(err)
+}
+
+func (t *chainedContext) Mock(typ reflect.Type, obj interface{}) error {
+	return ErrNotSupported
+}
+
+/**
+	Replace the bean in all contexts where it is registered, returns true if any of the contexts replaced it
+ */
+func (t *chainedContext) AtomicReplace(expected, replacement interface{}) (bool, error) {
+	if t.IsFrozen() {
+		return false, ErrContextFrozen
+	}
+	swapped := false
+	for _, ctx := range t.contexts {
+		ok, err := ctx.AtomicReplace(expected, replacement)
+		if err != nil {
+			return swapped, err
+		}
+		swapped = swapped || ok
+	}
+	return swapped, nil
+}
+
+func (t *chainedContext) RecreateBean(typ reflect.Type) error {
+	return ErrNotSupported
+}
+
+/**
+	Snapshot of the chain is empty, Restore() of it returns an error
+ */
+func (t *chainedContext) Snapshot() ContextSnapshot {
+	return ContextSnapshot{}
+}
+
+func (t *chainedContext) Restore(s ContextSnapshot) error {
+	return ErrNotSupported
+}
+
+/**
+	Explanations of all contexts in order
+ */
+func (t *chainedContext) ExplainAmbiguity(ifaceType reflect.Type) string {
+	var out strings.Builder
+	for _, ctx := range t.contexts {
+		out.WriteString(ctx.ExplainAmbiguity(ifaceType))
+	}
+	return out.String()
+}
+
+/**
+	Bindings of all contexts, the first context has priority for the same type
+ */
+func (t *chainedContext) Bindings() []Binding {
+	var list []Binding
+	visited := make(map[reflect.Type]bool)
+	for _, ctx := range t.contexts {
+		for _, binding := range ctx.Bindings() {
+			if !visited[binding.InterfaceType] {
+				visited[binding.InterfaceType] = true
+				list = append(list, binding)
+			}
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].InterfaceType.String() < list[j].InterfaceType.String()
+	})
+	return list
+}
+
+/**
+	PhaseClosed after Close() of the chain, otherwise the latest phase if any context failed or is closing,
+	otherwise the earliest phase of contexts
+ */
+func (t *chainedContext) Phase() Phase {
+	select {
+	case <-t.done:
+		return PhaseClosed
+	default:
+	}
+	min, max := PhaseClosed, PhaseCreating
+	for _, ctx := range t.contexts {
+		phase := ctx.Phase()
+		if phase < min {
+			min = phase
+		}
+		if phase > max {
+			max = phase
+		}
+	}
+	if max >= PhaseFailed {
+		return max
+	}
+	return min
+}
+
+func (t *chainedContext) Diff(other Context) WiringDiff {
+	return wiringDiff(t, other)
+}
+
+/**
+	Sum of statistics of all contexts, CreatedAt is the latest one
+ */
+func (t *chainedContext) Stats() Stats {
+	stats := Stats{
+		Phase:    t.Phase(),
+		Beans:    len(t.Core()),
+		Bindings: len(t.Bindings()),
+	}
+	for _, ctx := range t.contexts {
+		s := ctx.Stats()
+		if s.CreatedAt.After(stats.CreatedAt) {
+			stats.CreatedAt = s.CreatedAt
+		}
+		stats.CreateDuration += s.CreateDuration
+		stats.InitDuration += s.InitDuration
+		stats.CacheHits += s.CacheHits
+		stats.CacheMisses += s.CacheMisses
+	}
+	return stats
+}
+
+func (t *chainedContext) Health() HealthReport {
+	report := HealthReport{
+		Overall:    HealthUp,
+		Indicators: make(map[string]HealthStatus),
+	}
+	for _, ctx := range t.contexts {
+		r := ctx.Health()
+		for name, status := range r.Indicators {
+			if _, ok := report.Indicators[name]; !ok {
+				report.Indicators[name] = status
+			}
+		}
+		switch {
+		case r.Overall == HealthDown:
+			report.Overall = HealthDown
+		case r.Overall != HealthUp && report.Overall == HealthUp:
+			report.Overall = HealthUnknown
+		}
+	}
+	return report
+}
+
+func (t *chainedContext) Observe(observer ContextObserver) {
+	for _, ctx := range t.contexts {
+		ctx.Observe(observer)
+	}
+}
+
+/**
+	Graphs of all contexts, the first context has priority for the same class
+ */
+func (t *chainedContext) Graph() Graph {
+	var graph Graph
+	visited := make(map[reflect.Type]bool)
+	for _, ctx := range t.contexts {
+		for _, node := range ctx.Graph().Nodes {
+			if !visited[node.Type] {
+				visited[node.Type] = true
+				graph.Nodes = append(graph.Nodes, node)
+			}
+		}
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool {
+		return graph.Nodes[i].Type.String() < graph.Nodes[j].Type.String()
+	})
+	return graph
+}
+
+func (t *chainedContext) InjectionGraph() map[reflect.Type][]reflect.Type {
+	graph := make(map[reflect.Type][]reflect.Type)
+	for _, ctx := range t.contexts {
+		for classPtr, list := range ctx.InjectionGraph() {
+			if _, ok := graph[classPtr]; !ok {
+				graph[classPtr] = list
+			}
+		}
+	}
+	return graph
+}
+
+func (t *chainedContext) Scan() []ScanResult {
+	var list []ScanResult
+	for _, ctx := range t.contexts {
+		list = append(list, ctx.Scan()...)
+	}
+	return list
+}
+
+func (t *chainedContext) GetBeanDefinition(typ reflect.Type) (BeanDefinition, bool) {
+	for _, ctx := range t.contexts {
+		if def, ok := ctx.GetBeanDefinition(typ); ok {
+			return def, true
+		}
+	}
+	return BeanDefinition{}, false
+}
+
+func (t *chainedContext) BeanMetadata(typ reflect.Type) map[string]string {
+	for _, ctx := range t.contexts {
+		if _, ok := ctx.Bean(typ); ok {
+			return ctx.BeanMetadata(typ)
+		}
+	}
+	return nil
+}
+
+func (t *chainedContext) FindByMetadata(key, value string) []interface{} {
+	var list []interface{}
+	for _, ctx := range t.contexts {
+		list = append(list, ctx.FindByMetadata(key, value)...)
+	}
+	return list
+}
+
+func (t *chainedContext) ExtractBeans(pkg string) []interface{} {
+	var list []interface{}
+	for _, ctx := range t.contexts {
+		list = append(list, ctx.ExtractBeans(pkg)...)
+	}
+	return list
+}
+
+/**
+	Create child context with the chain as parent, Close() of the chain does not close the child
+ */
+func (t *chainedContext) Fork(scan ...interface{}) (Context, error) {
+	return CreateWithOptions(scan, WithParent(t))
+}
+
+/**
+	Chain of copies of all contexts
+ */
+func (t *chainedContext) Clone() Context {
+	clones := make([]Context, len(t.contexts))
+	for i, ctx := range t.contexts {
+		clones[i] = ctx.Clone()
+	}
+	return Chain(clones...)
+}
+
+/**
+	Copy of the chain with the metadata value, the copy shares contexts and state of the chain
+ */
+func (t *chainedContext) With(key, value interface{}) Context {
+	if key == nil {
+		panic("nil key")
+	}
+	if !reflect.TypeOf(key).Comparable() {
+		panic("key is not comparable")
+	}
+	copied := make(map[interface{}]interface{}, len(t.metadata) + 1)
+	for k, v := range t.metadata {
+		copied[k] = v
+	}
+	copied[key] = value
+	return &chainedContext{contexts: t.contexts, metadata: copied, chainState: t.chainState}
+}
+
+/**
+	Chain does not have parent context
+ */
+func (t *chainedContext) Unwrap() Context {
+	return nil
+}
+
+/**
+	Implementation of the standard context.Context interface
+ */
+
+func (t *chainedContext) Deadline() (deadline time.Time, ok bool) {
+	return
+}
+
+func (t *chainedContext) Done() <-chan struct{} {
+	return t.done
+}
+
+func (t *chainedContext) Err() error {
+	select {
+	case <-t.done:
+		return stdcontext.Canceled
+	default:
+		return nil
+	}
+}
+
+/**
+	Returns the metadata value if found, the bean if key is reflect.Type, otherwise the first value of contexts
+ */
+func (t *chainedContext) Value(key interface{}) interface{} {
+	if value, ok := t.metadata[key]; ok {
+		return value
+	}
+	if typ, ok := key.(reflect.Type); ok {
+		if b, ok := t.Bean(typ); ok {
+			return b
+		}
+		return nil
+	}
+	for _, ctx := range t.contexts {
+		if value := ctx.Value(key); value != nil {
+			return value
+		}
+	}
+	return nil
+}
+
+/**
+	Inject each field by the first bean found in the contexts, fields with qualifier are searched by name
+ */
+/**
+	Inject with options and hooks of the first context, beans are searched in all contexts
+ */
+func (t *chainedContext) Inject(obj interface{}) (injected []string, err error) {
+	if t.IsFrozen() {
+		return nil, ErrContextFrozen
+	}
+	if c, ok := injectingContextOf(t.contexts[0]); ok {
+		return c.injectWith(t, obj)
+	}
+	return t.contexts[0].Inject(obj)
+}
+
+/**
+	Find the bean for inject field in contexts in order
+ */
+func (t *chainedContext) findInjected(caller reflect.Type, inject *injectionDef) (*bean, bool) {
+	for _, ctx := range t.contexts {
+		if r, ok := injectionResolverOf(ctx); ok {
+			if b, ok := r.findInjected(caller, inject); ok {
+				return b, true
+			}
+		}
+	}
+	return nil, false
+}
+
+/**
+	Context which options and hooks are used by Inject()
+ */
+func injectingContextOf(ctx Context) (*context, bool) {
+	switch c := ctx.(type) {
+	case *context:
+		return c, true
+	case *valueContext:
+		return c.context, true
+	case *chainedContext:
+		return injectingContextOf(c.contexts[0])
+	case *asyncContext:
+		return injectingContextOf(c.wait())
+	default:
+		return nil, false
+	}
+}
+
+func injectionResolverOf(ctx Context) (injectionResolver, bool) {
+	switch c := ctx.(type) {
+	case *context:
+		return c, true
+	case *valueContext:
+		return c.context, true
+	case *chainedContext:
+		return c, true
+	case *asyncContext:
+		return injectionResolverOf(c.wait())
+	default:
+		return nil, false
+	}
+}
+
+/**
+	Create provider function of the type func() T that searches the bean in all contexts on each call
+ */
+func (t *chainedContext) provider(funcType reflect.Type) reflect.Value {
+	typ := funcType.Out(0)
+	return reflect.MakeFunc(funcType, func([]reflect.Value) []reflect.Value {
+		result := reflect.New(typ).Elem()
+		result.Set(reflect.ValueOf(t.MustBean(typ)))
+		return []reflect.Value{result}
+	})
+}
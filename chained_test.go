@@ -0,0 +1,163 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestChain(t *testing.T) {
+
+	context.Verbose = false
+
+	first := &configStorage{}
+	firstCtx, err := context.Create(first, context.Interface((*Storage)(nil)))
+	require.Nil(t, err)
+
+	second := &memoryStorage{}
+	config := &configServiceImpl{}
+	secondCtx, err := context.Create(second, config, &struct{ Storage *memoryStorage `inject` }{})
+	require.Nil(t, err)
+
+	ctx := context.Chain(firstCtx, secondCtx)
+
+	require.Equal(t, first, ctx.MustBean(StorageClass))
+	require.Equal(t, config, ctx.MustBean(ConfigServiceClass))
+	_, ok := ctx.Bean(UserServiceClass)
+	require.False(t, ok)
+
+	require.Equal(t, context.BeanSlice{first, second}, ctx.Lookup("context_test.Storage"))
+	require.Equal(t, 2, ctx.FindAll(StorageClass).Len())
+	require.Equal(t, len(firstCtx.Core()) + len(secondCtx.Core()), len(ctx.Core()))
+
+	holder := &struct {
+		Storage       Storage       `inject`
+		ConfigService ConfigService `inject`
+		Optional      UserService   `inject:"optional"`
+	}{}
+	injected, err := ctx.Inject(holder)
+	require.Nil(t, err)
+	require.Equal(t, []string{"Storage", "ConfigService"}, injected)
+	require.Equal(t, Storage(first), holder.Storage)
+
+	_, err = ctx.Inject(&struct{ UserService UserService `inject` }{})
+	require.NotNil(t, err)
+
+	require.Nil(t, ctx.Close())
+	require.Equal(t, context.PhaseClosed, firstCtx.Phase())
+	require.Equal(t, context.PhaseClosed, secondCtx.Phase())
+
+}
+
+func TestChainAllContexts(t *testing.T) {
+
+	context.Verbose = false
+
+	first := &configStorage{}
+	firstCtx, err := context.Create(first, &databaseHealth{})
+	require.Nil(t, err)
+
+	second := &memoryStorage{}
+	secondCtx, err := context.Create(second, &configServiceImpl{}, &cacheHealth{})
+	require.Nil(t, err)
+
+	ctx := context.Chain(firstCtx, secondCtx)
+	defer ctx.Close()
+
+	report := ctx.Health()
+	require.Equal(t, context.HealthDown, report.Overall)
+	require.Equal(t, 2, len(report.Indicators))
+
+	require.Nil(t, ctx.RequireExactly(ctx.Core()...))
+	require.NotNil(t, ctx.RequireExactly(firstCtx.Core()...))
+	require.Equal(t, len(firstCtx.Scan()) + len(secondCtx.Scan()), len(ctx.Scan()))
+	require.Equal(t, 5, len(ctx.ExtractBeans("github.com/consensusdb/context_test")))
+
+	resolved, _, err := ctx.Resolve(ConfigServiceClass)
+	require.Nil(t, err)
+	require.Equal(t, reflect.TypeOf(&configServiceImpl{}), resolved)
+
+	require.Equal(t, context.ErrNotSupported, ctx.Mock(StorageClass, &memoryStorage{}))
+	require.Equal(t, context.ErrNotSupported, ctx.RecreateBean(StorageClass))
+
+	replacement := &memoryStorage{}
+	swapped, err := ctx.AtomicReplace(second, replacement)
+	require.Nil(t, err)
+	require.True(t, swapped)
+	require.Equal(t, replacement, ctx.MustBean(reflect.TypeOf(second)))
+
+	// provider fields are resolved in all contexts
+	holder := &struct {
+		Storage       func() Storage       `inject`
+		ConfigService func() ConfigService `inject`
+	}{}
+	injected, err := ctx.Inject(holder)
+	require.Nil(t, err)
+	require.Equal(t, []string{"Storage", "ConfigService"}, injected)
+	require.Equal(t, Storage(first), holder.Storage())
+	require.NotNil(t, holder.ConfigService())
+
+	ctx.Freeze()
+	require.True(t, firstCtx.IsFrozen())
+	require.True(t, secondCtx.IsFrozen())
+	require.True(t, ctx.IsFrozen())
+	_, err = ctx.Inject(&struct{ Storage Storage `inject` }{})
+	require.Equal(t, context.ErrContextFrozen, err)
+
+}
+
+type chainedHandler struct {
+	Storage     Storage     `inject`
+	UserService UserService `inject`
+	Host        string      `value:"${server.host}"`
+}
+
+func TestChainInjectProperties(t *testing.T) {
+
+	context.Verbose = false
+
+	var audited []string
+	storage := &configStorage{}
+	storageCtx, err := context.CreateWithOptions([]interface{}{storage, context.Interface((*Storage)(nil))},
+		context.WithPropertySource(context.MapPropertySource{"server.host": "localhost"}),
+		context.WithAfterInject(func(obj interface{}, bd context.BeanDefinition, injected []string) {
+			audited = injected
+		}))
+	require.Nil(t, err)
+
+	userService := &userServiceImpl{}
+	userCtx, err := context.Create(&configStorage{}, &configServiceImpl{}, userService)
+	require.Nil(t, err)
+
+	handler := &chainedHandler{}
+	_, err = context.Chain(storageCtx, userCtx).Inject(handler)
+	require.Nil(t, err)
+	require.Equal(t, Storage(storage), handler.Storage)
+	require.Equal(t, UserService(userService), handler.UserService)
+	require.Equal(t, "localhost", handler.Host)
+	require.Equal(t, []string{"Storage", "UserService"}, audited)
+
+}
@@ -0,0 +1,59 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+/**
+@author Alex Shvid
+*/
+
+func (t *context) Clone() Context {
+	c := &context{
+		core:           t.coreBeans(),
+		conf:           t.conf,
+		parent:         t.parent,
+		phase:          int32(t.Phase()),
+		createdAt:      t.createdAt,
+		createDuration: t.createDuration,
+		initDuration:   t.initDuration,
+		done:           make(chan struct{}),
+		closed:         make(chan struct{}),
+		trace:          t.trace,
+		prototypes:     t.prototypes,
+		cloned:         true,
+	}
+	c.registry.init(t.conf)
+	t.registry.RLock()
+	for typ, b := range t.registry.beansByType {
+		c.registry.beansByType[typ] = b
+	}
+	for typ := range t.registry.lazyTypes {
+		c.registry.lazyTypes[typ] = true
+	}
+	for name, list := range t.registry.beansByName {
+		c.registry.beansByName[name] = append([]*bean(nil), list...)
+	}
+	t.registry.RUnlock()
+	t.observersMu.RLock()
+	c.observers = append([]ContextObserver(nil), t.observers...)
+	t.observersMu.RUnlock()
+	t.providedMu.RLock()
+	c.provided = append([]*bean(nil), t.provided...)
+	t.providedMu.RUnlock()
+	return c
+}
@@ -0,0 +1,59 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestClone(t *testing.T) {
+
+	context.Verbose = false
+
+	storage := &configStorage{}
+	ctx, err := context.Create(storage, &configServiceImpl{}, context.Interface((*Storage)(nil)))
+	require.Nil(t, err)
+
+	clone := ctx.Clone()
+	require.ElementsMatch(t, ctx.Core(), clone.Core())
+	require.Equal(t, ctx.BeanNames(), clone.BeanNames())
+
+	mock := &memoryStorage{}
+	require.Nil(t, clone.Mock(StorageClass, mock))
+
+	require.Equal(t, mock, clone.MustBean(StorageClass))
+	require.Equal(t, storage, ctx.MustBean(StorageClass))
+	require.Equal(t, context.BeanSlice{storage}, ctx.Lookup("context_test.Storage"))
+
+	holder := &struct{ Storage Storage `inject` }{}
+	_, err = clone.Inject(holder)
+	require.Nil(t, err)
+	require.Equal(t, Storage(mock), holder.Storage)
+
+	require.Nil(t, clone.Close())
+	require.Equal(t, context.PhaseReady, ctx.Phase())
+	require.Nil(t, ctx.Close())
+
+}
@@ -0,0 +1,105 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"reflect"
+	"sync"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Split core in to the sets of independent beans in destroy order.
+	Each set contains beans that are not dependencies of beans in the following sets.
+	Beans in a dependency cycle are placed in to the last set.
+ */
+func destroyLevels(core map[reflect.Type]*bean) [][]*bean {
+	dependents := make(map[*bean]int)
+	var remaining []*bean
+	for _, b := range core {
+		remaining = append(remaining, b)
+		for _, d := range b.dependencies {
+			dependents[d]++
+		}
+	}
+	var levels [][]*bean
+	for len(remaining) > 0 {
+		var level, next []*bean
+		for _, b := range remaining {
+			if dependents[b] == 0 {
+				level = append(level, b)
+			} else {
+				next = append(next, b)
+			}
+		}
+		if len(level) == 0 {
+			levels = append(levels, next)
+			break
+		}
+		for _, b := range level {
+			for _, d := range b.dependencies {
+				dependents[d]--
+			}
+		}
+		levels = append(levels, level)
+		remaining = next
+	}
+	return levels
+}
+
+func (t *context) closeParallel(n int) error {
+	var err []error
+	var mu sync.Mutex
+	semaphore := make(chan struct{}, n)
+	for _, level := range destroyLevels(t.coreBeans()) {
+		var wg sync.WaitGroup
+		for _, instance := range level {
+			if c, ok := instance.obj.(DisposableBean); ok {
+				wg.Add(1)
+				semaphore <- struct{}{}
+				go func(c DisposableBean) {
+					defer func() {
+						<-semaphore
+						wg.Done()
+					}()
+					if e := t.destroyBean(c); e != nil && !t.handleError(LifecycleDestroy, e) {
+						mu.Lock()
+						err = append(err, e)
+						mu.Unlock()
+					}
+				}(c)
+			}
+		}
+		wg.Wait()
+	}
+	return multiple(err)
+}
+
+func (t *context) AsyncClose() <-chan error {
+	t.asyncCloseOnce.Do(func() {
+		t.asyncClose = make(chan error, 1)
+		go func() {
+			t.asyncClose <- t.Close()
+		}()
+	})
+	return t.asyncClose
+}
@@ -0,0 +1,142 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"errors"
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type slowCloser[N any] struct {
+	delay time.Duration
+}
+
+func (t *slowCloser[N]) Destroy() error {
+	time.Sleep(t.delay)
+	return nil
+}
+
+func TestCloseParallel(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.CreateWithOptions([]interface{}{
+		&slowCloser[[1]int]{delay: 50 * time.Millisecond},
+		&slowCloser[[2]int]{delay: 50 * time.Millisecond},
+		&slowCloser[[3]int]{delay: 50 * time.Millisecond},
+		&slowCloser[[4]int]{delay: 50 * time.Millisecond},
+		&slowCloser[[5]int]{delay: 50 * time.Millisecond},
+	}, context.WithCloseParallelism(5))
+	require.Nil(t, err)
+
+	start := time.Now()
+	require.Nil(t, ctx.Close())
+	require.True(t, time.Since(start) < 100*time.Millisecond)
+
+}
+
+type closeRecorder struct {
+	sync.Mutex
+	order []string
+}
+
+func (t *closeRecorder) record(name string) {
+	t.Lock()
+	defer t.Unlock()
+	t.order = append(t.order, name)
+}
+
+type closingDependency struct {
+	recorder *closeRecorder
+}
+
+func (t *closingDependency) Destroy() error {
+	t.recorder.record("dependency")
+	return nil
+}
+
+type closingService struct {
+	Dependency *closingDependency `inject`
+	recorder   *closeRecorder
+}
+
+func (t *closingService) Destroy() error {
+	time.Sleep(10 * time.Millisecond)
+	t.recorder.record("service")
+	return nil
+}
+
+func TestCloseParallelOrder(t *testing.T) {
+
+	context.Verbose = false
+	recorder := &closeRecorder{}
+
+	ctx, err := context.CreateWithOptions([]interface{}{
+		&closingDependency{recorder: recorder},
+		&closingService{recorder: recorder},
+	}, context.WithCloseParallelism(2))
+	require.Nil(t, err)
+
+	require.Nil(t, ctx.Close())
+	require.Equal(t, []string{"service", "dependency"}, recorder.order)
+
+}
+
+type failingCloser struct {
+	destroyed int32
+}
+
+func (t *failingCloser) Destroy() error {
+	atomic.AddInt32(&t.destroyed, 1)
+	return errors.New("disk is busy")
+}
+
+func TestAsyncClose(t *testing.T) {
+
+	context.Verbose = false
+
+	syncCtx, err := context.Create(&failingCloser{})
+	require.Nil(t, err)
+	expected := syncCtx.Close()
+	require.NotNil(t, expected)
+
+	closer := &failingCloser{}
+	ctx, err := context.Create(closer)
+	require.Nil(t, err)
+
+	done := ctx.AsyncClose()
+	require.True(t, done == ctx.AsyncClose())
+
+	// in-flight work continues while closing
+	require.Equal(t, closer, ctx.MustBean(reflect.TypeOf(closer)))
+
+	require.Equal(t, expected, <-done)
+	require.Equal(t, int32(1), atomic.LoadInt32(&closer.destroyed))
+
+}
@@ -0,0 +1,28 @@
+// Code generated by contextgen. DO NOT EDIT.
+
+package example
+
+import (
+	"github.com/consensusdb/context"
+)
+
+// AppContext provides type-safe accessors of beans
+type AppContext struct {
+	context.Context
+}
+
+func NewAppContext(ctx context.Context) *AppContext {
+	return &AppContext{Context: ctx}
+}
+
+func (t *AppContext) Storage() Storage {
+	return context.MustBeanOf[Storage](t.Context)
+}
+
+func (t *AppContext) UserService() UserService {
+	return context.MustBeanOf[UserService](t.Context)
+}
+
+func (t *AppContext) UserServiceImpl() *UserServiceImpl {
+	return context.MustBeanOf[*UserServiceImpl](t.Context)
+}
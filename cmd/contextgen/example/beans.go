@@ -0,0 +1,63 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package example
+
+/**
+@author Alex Shvid
+*/
+
+//go:generate go run .. -config context.yaml -out appcontext_gen.go
+
+type Storage interface {
+	Load(key string) string
+	Store(key, value string)
+}
+
+type UserService interface {
+	GetUser(user string) string
+	SaveUser(user, details string)
+}
+
+type storageImpl struct {
+	internal map[string]string
+}
+
+func NewStorage() *storageImpl {
+	return &storageImpl{internal: make(map[string]string)}
+}
+
+func (t *storageImpl) Load(key string) string {
+	return t.internal[key]
+}
+
+func (t *storageImpl) Store(key, value string) {
+	t.internal[key] = value
+}
+
+type UserServiceImpl struct {
+	Storage Storage `inject`
+}
+
+func (t *UserServiceImpl) GetUser(user string) string {
+	return t.Storage.Load("user:" + user)
+}
+
+func (t *UserServiceImpl) SaveUser(user, details string) {
+	t.Storage.Store("user:" + user, details)
+}
@@ -0,0 +1,53 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package example_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/consensusdb/context/cmd/contextgen/example"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestAppContext(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create(
+		example.NewStorage(),
+		&example.UserServiceImpl{},
+		context.Interface((*example.Storage)(nil)),
+		context.Interface((*example.UserService)(nil)),
+	)
+	require.Nil(t, err)
+
+	app := example.NewAppContext(ctx)
+	require.NotNil(t, app.Storage())
+	require.NotNil(t, app.UserService())
+	require.NotNil(t, app.UserServiceImpl())
+
+	app.UserService().SaveUser("alex", "admin")
+	require.Equal(t, "admin", app.Storage().Load("user:alex"))
+	require.Equal(t, app.UserServiceImpl(), app.UserService())
+
+}
@@ -0,0 +1,132 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"bytes"
+	"github.com/consensusdb/context"
+	"github.com/pkg/errors"
+	"go/format"
+	"go/token"
+	"gopkg.in/yaml.v3"
+	"reflect"
+	"text/template"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Configuration of the generator, for example
+
+		package: app
+		context: AppContext
+		imports:
+		  - github.com/company/app/storage
+		beans:
+		  - name: UserService
+		    type: UserService
+		  - name: Storage
+		    type: storage.Storage
+ */
+
+type config struct {
+	Package string       `yaml:"package"`
+	Context string       `yaml:"context"`
+	Imports []string     `yaml:"imports"`
+	Beans   []beanConfig `yaml:"beans"`
+}
+
+type beanConfig struct {
+	/**
+		Name of the accessor method
+	 */
+	Name string `yaml:"name"`
+
+	/**
+		Go type of the bean, pointer to the structure or interface
+	 */
+	Type string `yaml:"type"`
+}
+
+func parseConfig(data []byte) (*config, error) {
+	cfg := &config{Context: "AppContext"}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, errors.Wrap(err, "invalid configuration")
+	}
+	if cfg.Package == "" {
+		return nil, errors.New("package is not defined")
+	}
+	if !token.IsIdentifier(cfg.Context) {
+		return nil, errors.Errorf("invalid context name '%s'", cfg.Context)
+	}
+	for i, b := range cfg.Beans {
+		if !token.IsIdentifier(b.Name) || !token.IsExported(b.Name) {
+			return nil, errors.Errorf("invalid exported accessor name '%s' of bean on position %d", b.Name, i)
+		}
+		if _, ok := contextClass.MethodByName(b.Name); ok {
+			return nil, errors.Errorf("accessor name '%s' conflicts with method of context.Context", b.Name)
+		}
+		if b.Type == "" {
+			return nil, errors.Errorf("type of bean '%s' is not defined", b.Name)
+		}
+	}
+	return cfg, nil
+}
+
+var contextClass = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+var codeTemplate = template.Must(template.New("context").Parse(`// Code generated by contextgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"github.com/consensusdb/context"
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+
+// {{.Context}} provides type-safe accessors of beans
+type {{.Context}} struct {
+	context.Context
+}
+
+func New{{.Context}}(ctx context.Context) *{{.Context}} {
+	return &{{.Context}}{Context: ctx}
+}
+{{range .Beans}}
+func (t *{{$.Context}}) {{.Name}}() {{.Type}} {
+	return context.MustBeanOf[{{.Type}}](t.Context)
+}
+{{end -}}
+`))
+
+func generate(cfg *config) ([]byte, error) {
+	var out bytes.Buffer
+	if err := codeTemplate.Execute(&out, cfg); err != nil {
+		return nil, err
+	}
+	code, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid generated code\n%s", out.String())
+	}
+	return code, nil
+}
@@ -0,0 +1,81 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestGenerateExample(t *testing.T) {
+
+	data, err := os.ReadFile("example/context.yaml")
+	require.Nil(t, err)
+
+	cfg, err := parseConfig(data)
+	require.Nil(t, err)
+
+	code, err := generate(cfg)
+	require.Nil(t, err)
+
+	expected, err := os.ReadFile("example/appcontext_gen.go")
+	require.Nil(t, err)
+	require.Equal(t, string(expected), string(code), "run go generate in example")
+
+}
+
+func TestGenerateImports(t *testing.T) {
+
+	cfg, err := parseConfig([]byte(`
+package: app
+imports:
+  - github.com/company/app/storage
+beans:
+  - name: Storage
+    type: storage.Storage
+`))
+	require.Nil(t, err)
+	require.Equal(t, "AppContext", cfg.Context)
+
+	code, err := generate(cfg)
+	require.Nil(t, err)
+	require.Contains(t, string(code), "\"github.com/company/app/storage\"")
+	require.Contains(t, string(code), "func (t *AppContext) Storage() storage.Storage {")
+
+}
+
+func TestInvalidConfig(t *testing.T) {
+
+	for _, data := range []string{
+		"beans: []",
+		"package: app\nbeans:\n  - name: storage\n    type: Storage",
+		"package: app\nbeans:\n  - name: Storage",
+		"package: app\nbeans:\n  - name: Close\n    type: Storage",
+		"package: app\ncontext: App Context",
+	} {
+		_, err := parseConfig([]byte(data))
+		require.NotNil(t, err, data)
+	}
+
+}
@@ -0,0 +1,18 @@
+module github.com/consensusdb/context/cmd/contextgen
+
+go 1.18
+
+require (
+	github.com/consensusdb/context v0.0.0
+	github.com/pkg/errors v0.9.1
+	github.com/stretchr/testify v1.6.1
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/kr/text v0.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+)
+
+replace github.com/consensusdb/context => ../../
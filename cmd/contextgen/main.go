@@ -0,0 +1,63 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Generator of type-safe accessors for the context.
+
+	Example:
+		//go:generate contextgen -config context.yaml -out appcontext_gen.go
+ */
+
+func main() {
+	config := flag.String("config", "context.yaml", "configuration file with the list of beans")
+	out := flag.String("out", "context_gen.go", "output file")
+	flag.Parse()
+
+	if err := run(*config, *out); err != nil {
+		fmt.Fprintf(os.Stderr, "contextgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(configFile, outFile string) error {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return err
+	}
+	cfg, err := parseConfig(data)
+	if err != nil {
+		return err
+	}
+	code, err := generate(cfg)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outFile, code, 0644)
+}
@@ -0,0 +1,166 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"reflect"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Condition of registration of the bean in context
+
+	Example:
+		type redisCache struct {
+			Conn *redis.Conn `inject`
+		}
+
+		func (t *redisCache) Condition() context.Condition {
+			return context.ConditionFunc(func(ctx context.ConditionContext) bool {
+				return ctx.ContainsBean(reflect.TypeOf((*redis.Conn)(nil)))
+			})
+		}
+ */
+
+type Condition interface {
+	Matches(ctx ConditionContext) bool
+}
+
+/**
+	Function that implements Condition
+ */
+
+type ConditionFunc func(ctx ConditionContext) bool
+
+func (f ConditionFunc) Matches(ctx ConditionContext) bool {
+	return f(ctx)
+}
+
+/**
+	State of the context available for conditions
+ */
+
+type ConditionContext interface {
+
+	/**
+		Check if the bean of the class or implementation of the interface is already registered
+	 */
+	ContainsBean(typ reflect.Type) bool
+
+	/**
+		Get property from property sources, empty if not found
+	 */
+	GetProperty(key string) string
+
+	/**
+		Profiles enabled by WithActiveProfiles
+	 */
+	ActiveProfiles() []string
+}
+
+/**
+	Bean that is registered in context only if condition matches
+ */
+
+type Conditional interface {
+	Condition() Condition
+}
+
+/**
+	Enable profiles available for conditions through ConditionContext
+ */
+func WithActiveProfiles(profiles ...string) Option {
+	return func(conf *contextConfig) {
+		conf.profiles = append(conf.profiles, profiles...)
+	}
+}
+
+type conditionContext struct {
+	conf    *contextConfig
+	classes []reflect.Type
+}
+
+func (t *conditionContext) ContainsBean(typ reflect.Type) bool {
+	for _, classPtr := range t.classes {
+		if classPtr == typ || (typ.Kind() == reflect.Interface && classPtr.Implements(typ)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *conditionContext) GetProperty(key string) string {
+	for _, source := range t.conf.propertySources {
+		if value, ok := source.GetProperty(key); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+func (t *conditionContext) ActiveProfiles() []string {
+	return append([]string(nil), t.conf.profiles...)
+}
+
+/**
+	Remove conditional beans that do not match from the scan list.
+	Conditions are evaluated in scan order with all unconditional beans and preceding matched conditional beans registered.
+ */
+func filterConditional(scan []interface{}, conf *contextConfig) []interface{} {
+	condCtx := &conditionContext{conf: conf}
+	hasConditional := false
+	for _, obj := range scan {
+		if _, ok := obj.(Conditional); ok {
+			hasConditional = true
+		} else if classPtr := scanType(obj); classPtr != nil {
+			condCtx.classes = append(condCtx.classes, classPtr)
+		}
+	}
+	if !hasConditional {
+		return scan
+	}
+	var list []interface{}
+	for _, obj := range scan {
+		if c, ok := obj.(Conditional); ok {
+			if cond := c.Condition(); cond != nil && !cond.Matches(condCtx) {
+				continue
+			}
+			condCtx.classes = append(condCtx.classes, scanType(obj))
+		}
+		list = append(list, obj)
+	}
+	return list
+}
+
+/**
+	Class of the object in scan list, nil for interface markers and nil objects
+ */
+func scanType(obj interface{}) reflect.Type {
+	switch o := obj.(type) {
+	case nil, interfaceMarker:
+		return nil
+	case registeredBean:
+		return o.registeredType()
+	default:
+		return reflect.TypeOf(obj)
+	}
+}
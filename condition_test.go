@@ -0,0 +1,103 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type redisConnection struct {
+}
+
+var redisConnectionClass = reflect.TypeOf((*redisConnection)(nil))
+
+type redisCache struct {
+	Conn *redisConnection `inject`
+}
+
+var redisCacheClass = reflect.TypeOf((*redisCache)(nil))
+
+func (t *redisCache) Condition() context.Condition {
+	return context.ConditionFunc(func(ctx context.ConditionContext) bool {
+		return ctx.ContainsBean(redisConnectionClass)
+	})
+}
+
+func TestConditional(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create(&redisCache{}, &redisConnection{})
+	require.Nil(t, err)
+	require.Equal(t, 2, len(ctx.Core()))
+	_, ok := ctx.Bean(redisCacheClass)
+	require.True(t, ok)
+
+	ctx, err = context.Create(&redisCache{})
+	require.Nil(t, err)
+	require.Equal(t, 0, len(ctx.Core()))
+	_, ok = ctx.Bean(redisCacheClass)
+	require.False(t, ok)
+
+}
+
+type profiledStorage struct {
+	configStorage
+}
+
+func (t *profiledStorage) Condition() context.Condition {
+	return context.ConditionFunc(func(ctx context.ConditionContext) bool {
+		for _, profile := range ctx.ActiveProfiles() {
+			if profile == "test" {
+				return ctx.GetProperty("storage.type") == "memory" && !ctx.ContainsBean(StorageClass)
+			}
+		}
+		return false
+	})
+}
+
+func TestConditionContext(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.CreateWithOptions([]interface{}{&profiledStorage{}},
+		context.WithActiveProfiles("test"),
+		context.WithPropertySource(context.MapPropertySource{"storage.type": "memory"}))
+	require.Nil(t, err)
+	require.Equal(t, 1, len(ctx.Core()))
+
+	ctx, err = context.CreateWithOptions([]interface{}{&profiledStorage{}},
+		context.WithPropertySource(context.MapPropertySource{"storage.type": "memory"}))
+	require.Nil(t, err)
+	require.Equal(t, 0, len(ctx.Core()))
+
+	ctx, err = context.CreateWithOptions([]interface{}{&configStorage{}, &profiledStorage{}},
+		context.WithActiveProfiles("test"),
+		context.WithPropertySource(context.MapPropertySource{"storage.type": "memory"}))
+	require.Nil(t, err)
+	require.Equal(t, 1, len(ctx.Core()))
+
+}
@@ -19,11 +19,16 @@
 package context
 
 import (
+	stdcontext "context"
 	"fmt"
 	"github.com/pkg/errors"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 /**
@@ -35,10 +40,20 @@ type context struct {
 
 	/**
 		All instances scanned on creation of context.
-	    No modifications on runtime.
+	    The map is never modified in place, RecreateBean() and AtomicReplace() swap it with a copy under the registry lock.
 	 */
 	core map[reflect.Type]*bean
 
+	/**
+		Configuration of the context from options
+	 */
+	conf *contextConfig
+
+	/**
+		Parent context, nil for root context
+	 */
+	parent Context
+
 	/**
 		Fast search of beans by faceType and name
 	 */
@@ -49,71 +64,365 @@ type context struct {
 		Cache bean descriptions for Inject calls in runtime
 	 */
 	runtimeCache   sync.Map  // key is reflect.Type (classPtr), value is *beanDef
+
+	/**
+		Hits and misses of the runtime cache, atomic access
+	 */
+	cacheHits   int64
+	cacheMisses int64
+
+	/**
+		Current lifecycle phase, atomic access
+	 */
+	phase int32
+
+	/**
+		Time of creation of context and duration of wiring and initialization
+	 */
+	createdAt      time.Time
+	createDuration time.Duration
+	initDuration   time.Duration
+
+	/**
+		Closed on Close() of the context, see Done()
+	 */
+	done     chan struct{}
+	doneOnce sync.Once
+
+	/**
+		Serialize concurrent calls of Refresh()
+	 */
+	refreshMu sync.Mutex
+
+	/**
+		Result of Close() started by AsyncClose()
+	 */
+	asyncClose     chan error
+	asyncCloseOnce sync.Once
+
+	/**
+		Timing of injections and initialization on Create()
+	 */
+	trace Trace
+
+	/**
+		Observers of lifecycle events, see Observe()
+	 */
+	observers   []ContextObserver
+	observersMu sync.RWMutex
+
+	/**
+		Closed when Close() finishes, see Wait()
+	 */
+	closed     chan struct{}
+	closedOnce sync.Once
+
+	/**
+		Beans are destroyed only by the first Close(), repeated calls return the same error
+	 */
+	closeOnce sync.Once
+	closeErr  error
+
+	/**
+		Error passed to the cancel function of CreateContext()
+	 */
+	cause   error
+	causeMu sync.Mutex
+
+	/**
+		Mutating operations are not allowed if not zero, atomic access
+	 */
+	frozen int32
+
+	/**
+		Prototype objects and child contexts that are not released yet, see Drain().
+		The channel is closed when the last object is released, nil if nothing is tracked.
+	 */
+	inflightObjs map[interface{}]bool
+	inflightIdle chan struct{}
+	inflightMu   sync.Mutex
+
+	/**
+		Non-singleton factories by the type of their objects, set on creation of context, see newPrototype()
+	 */
+	prototypes map[reflect.Type]*bean
+
+	/**
+		Channels closed on Close() before destruction of beans, see NotifyOnClose()
+	 */
+	closeNotify   []chan<- struct{}
+	closeNotifyMu sync.Mutex
+
+	/**
+		Error handlers in core that are initialized and not destroyed yet, see ErrorHandler
+	 */
+	activeHandlers   map[interface{}]bool
+	activeHandlersMu sync.RWMutex
+
+	/**
+		Beans registered on runtime by Provide(), not part of core
+	 */
+	provided   []*bean
+	providedMu sync.RWMutex
+
+	/**
+		Objects created by LookupOrCreate(), key is reflect.Type, value is *lazyObject
+	 */
+	lazy sync.Map
+
+	/**
+		Context created by Clone(), beans are owned by the original context
+	 */
+	cloned bool
 }
 
 
 func Create(scan... interface{}) (Context, error) {
+	return CreateWithOptions(scan)
+}
+
+func CreateWithOptions(scan []interface{}, options ...Option) (Context, error) {
+	conf := newContextConfig(options)
+	if conf.noop {
+		return NoopContext{}, nil
+	}
+	if conf.initTimeout > 0 {
+		return createWithTimeout(scan, conf)
+	}
+	return toContext(create(scan, conf, conf.background()))
+}
+
+/**
+	Bean and its position in the scan list
+ */
+type scannedBean struct {
+	position int
+	bean     *bean
+}
+
+/**
+	Avoid non-nil interface holding nil pointer
+ */
+func toContext(ctx *context, err error) (Context, error) {
+	if ctx == nil {
+		return nil, err
+	}
+	return ctx, err
+}
+
+func create(scan []interface{}, conf *contextConfig, goCtx stdcontext.Context) (_ *context, err error) {
 
-	beansByName := make(map[string][]*bean)
-	beansByType := make(map[reflect.Type]*bean)
+	start := time.Now()
+
+	for _, hook := range conf.beforeCreate {
+		hook(scan)
+	}
+
+	trace := newTracer(conf)
+	defer func() {
+		if err != nil {
+			trace.dump(err)
+		}
+	}()
 
 	core := make(map[reflect.Type]*bean)
 	pointers := make(map[reflect.Type][]*injection)
 	interfaces := make(map[reflect.Type][]*injection)
+	var providers []*injection
+	var named []*injection
+
+	scan, modules := expandModules(scan)
+	scan = filterConditional(scan, conf)
 
 	// scan
+	var investigated []*investigation
+	if conf.scanWorkers > 1 {
+		investigated = investigateConcurrently(scan, conf.scanWorkers)
+	}
+	var markers []interfaceMarker
+	var factories []scannedFactory
+	var factoryBeans []scannedBean
+	var scanned []scannedBean
+	positions := make(map[reflect.Type]int)
 	for i, obj := range scan {
+		if m, ok := obj.(interfaceMarker); ok {
+			if m.ifaceType == nil {
+				return nil, errors.Errorf("expected nil pointer to interface on position %d, but got '%v'", i, m.invalid)
+			}
+			markers = append(markers, m)
+			continue
+		}
+		if f, ok := obj.(functionFactory); ok {
+			if f.beanType == nil || f.factory == nil {
+				return nil, errors.Errorf("expected bean type and factory function on position %d", i)
+			}
+			factories = append(factories, scannedFactory{i, f})
+			continue
+		}
+		var classPtr reflect.Type
+		var name string
+		if n, ok := obj.(namedBean); ok {
+			name = n.name
+		}
+		if r, ok := obj.(registeredBean); ok {
+			obj, classPtr = r.registeredObject(), r.registeredType()
+		}
 		if obj == nil {
 			return nil, errors.Errorf("null core are not allowed on position %d", i)
 		}
-		classPtr := reflect.TypeOf(obj)
-		if Verbose {
-			fmt.Printf("Instance %v\n", classPtr)
+		if !conf.acceptType(reflect.TypeOf(obj)) {
+			trace.printf("Skip %T\n", obj)
+			continue
 		}
+		if classPtr == nil {
+			classPtr = reflect.TypeOf(obj)
+		}
+		trace.printf("Instance %v\n", classPtr)
 		if classPtr.Kind() != reflect.Ptr {
 			return nil, errors.Errorf("non-pointer instance is not allowed on position %d of type '%v'", i, classPtr)
 		}
 		if already, ok := core[classPtr]; ok {
-			return nil, errors.Errorf("repeated instance on position %d of type '%v' visited as '%v'", i, classPtr, already.beanDef.classPtr)
+			switch conf.duplicateStrategy {
+			case KeepFirst:
+				continue
+			case KeepLast:
+			default:
+				return nil, errors.Errorf("repeated instance on position %d of type '%v' visited as '%v'", i, classPtr, already.beanDef.classPtr)
+			}
+		}
+		var bean *bean
+		if investigated != nil && investigated[i] != nil {
+			bean, err = investigated[i].bean, investigated[i].err
+		} else {
+			bean, err = investigate(obj, classPtr)
 		}
-		bean, err := investigate(obj, classPtr)
 		if err != nil {
 			return nil, err
 		}
+		if mp, ok := obj.(MetadataProvider); ok {
+			bean.metadata = copyMetadata(mp.Metadata())
+		}
+		bean.position = i
+		bean.name = name
+		if j, ok := positions[classPtr]; ok {
+			scanned[j] = scannedBean{i, bean}
+		} else {
+			positions[classPtr] = len(scanned)
+			scanned = append(scanned, scannedBean{i, bean})
+		}
+		core[classPtr] = bean
+		if isFactoryBean(obj) {
+			factoryBeans = append(factoryBeans, scannedBean{i, bean})
+		}
+	}
+
+	ctx := &context{
+		core:      core,
+		conf:      conf,
+		parent:    conf.parent,
+		phase:     int32(PhaseCreating),
+		done:      make(chan struct{}),
+		closed:    make(chan struct{}),
+		observers: conf.observers,
+	}
+	ctx.registry.init(conf)
+
+	names := make(map[string]*bean)
+	for _, s := range scanned {
+		if s.bean.name != "" {
+			names[s.bean.name] = s.bean
+			ctx.registry.addBean(s.bean.beanDef.classPtr, s.bean)
+			ctx.registry.addName(s.bean.name, s.bean)
+		}
+	}
+
+	// factory beans
+	wired := make(map[*bean]bool)
+	for _, f := range factoryBeans {
+		trace.printf("Factory bean %v on position %d\n", f.bean.beanDef.classPtr, f.position)
+		if err := ctx.wireBean(f.bean); err != nil {
+			return nil, err
+		}
+		wired[f.bean] = true
+		if objectType, ok := prototypeFactory(f.bean.obj); ok {
+			trace.printf("Prototype factory of '%v'\n", objectType)
+			if ctx.prototypes == nil {
+				ctx.prototypes = make(map[reflect.Type]*bean)
+			}
+			ctx.prototypes[objectType] = f.bean
+			continue
+		}
+		product, err := ctx.createProduct(f)
+		if err != nil {
+			return nil, err
+		}
+		if !conf.acceptType(product.beanDef.classPtr) {
+			trace.printf("Skip %v\n", product.beanDef.classPtr)
+			continue
+		}
+		if _, ok := core[product.beanDef.classPtr]; ok {
+			return nil, errors.Errorf("repeated instance on position %d of type '%v' created by factory '%v'", f.position, product.beanDef.classPtr, f.bean.beanDef.classPtr)
+		}
+		core[product.beanDef.classPtr] = product
+		scanned = append(scanned, scannedBean{f.position, product})
+	}
+
+	// fields
+	for _, s := range scanned {
+		bean := s.bean
+		if wired[bean] {
+			continue
+		}
 		if len(bean.beanDef.fields) > 0 {
 			value := bean.valuePtr.Elem()
 			for _, injectDef := range bean.beanDef.fields {
-				if Verbose {
-					fmt.Printf("	Field %v\n", injectDef.fieldType)
+				trace.printf("	Field %v\n", injectDef.fieldType)
+				if injectDef.qualifier != "" && injectDef.fieldType.Kind() != reflect.Func {
+					named = append(named, &injection{value, injectDef, bean})
+					continue
 				}
 				switch injectDef.fieldType.Kind() {
 				case reflect.Ptr:
-					pointers[injectDef.fieldType] = append(pointers[injectDef.fieldType], &injection{value, injectDef})
+					pointers[injectDef.fieldType] = append(pointers[injectDef.fieldType], &injection{value, injectDef, bean})
 				case reflect.Interface:
-					interfaces[injectDef.fieldType] = append(interfaces[injectDef.fieldType], &injection{value, injectDef})
+					interfaces[injectDef.fieldType] = append(interfaces[injectDef.fieldType], &injection{value, injectDef, bean})
+				case reflect.Func:
+					providers = append(providers, &injection{value, injectDef, bean})
 				default:
-					return nil, errors.Errorf("injecting not a pointer or interface on field type '%v' at position %d in %v", injectDef.fieldType, i, classPtr)
+					return nil, errors.Errorf("injecting not a pointer or interface on field type '%v' at position %d in %v", injectDef.fieldType, s.position, bean.beanDef.classPtr)
 				}
 			}
 		}
-		core[classPtr] = bean
+	}
+
+	for _, s := range scanned {
+		ctx.notify(func(o ContextObserver) {
+			o.OnBeanRegistered(s.bean.beanDef.classPtr, s.bean.obj)
+		})
+	}
+
+	// properties
+	for _, b := range core {
+		if err := injectProperties(b.valuePtr.Elem(), b.beanDef, conf.propertySources); err != nil {
+			return nil, err
+		}
 	}
 
 	// direct match
 	var found []reflect.Type
 	for requiredType, injects := range pointers {
-		if direct, ok := core[requiredType]; ok {
+		direct, ok := core[requiredType]
+		if !ok {
+			direct, ok = ctx.parentBean(requiredType)
+		}
+		if ok {
 
-			beansByType[requiredType] = direct
-			name := requiredType.String()
-			beansByName[name] = append(beansByName[name], direct)
+			ctx.registry.addBean(requiredType, direct)
 
-			if Verbose {
-				fmt.Printf("Inject '%v' by pointer '%v' in to %+v\n", requiredType, direct.beanDef.classPtr, injects)
-			}
+			trace.printf("Inject '%v' by pointer '%v' in to %+v\n", requiredType, direct.beanDef.classPtr, injects)
 
 			for _, inject := range injects {
-				if err := inject.inject(direct); err != nil {
+				if err := ctx.injectTraced(inject, direct); err != nil {
 					return nil, err
 				}
 			}
@@ -125,39 +434,196 @@ func Create(scan... interface{}) (Context, error) {
 		for _, f := range found {
 			delete(pointers, f)
 		}
-		return nil, errorNoCandidates(pointers)
+		for requiredType, injects := range pointers {
+			if required := requiredOnly(injects); len(required) > 0 {
+				pointers[requiredType] = required
+			} else {
+				trace.printf("Skip optional '%v' in to %+v\n", requiredType, injects)
+				delete(pointers, requiredType)
+			}
+		}
+		if len(pointers) > 0 {
+			return nil, errorNoCandidates(pointers)
+		}
 	}
 
 	// interface match
 	for ifaceType, injects := range interfaces {
 
 		service, err := searchByInterface(ifaceType, core)
+		if err != nil && len(findCandidates(ifaceType, core)) == 0 {
+			if pb, ok := ctx.parentBean(ifaceType); ok {
+				service, err = pb, nil
+			}
+		}
 		if err != nil {
+			if candidates := findCandidates(ifaceType, core); len(candidates) > 1 {
+				return nil, newAmbiguityError(errors.Errorf("%v, required by those injections: %v", err, injects), ifaceType, candidates, injects)
+			}
+			if len(conf.converters) > 0 {
+				source, conv, convErr := ctx.findConvertible(ifaceType)
+				if convErr == nil {
+					trace.printf("Inject '%v' by converter '%T' of '%v' in to %+v\n", ifaceType, conv, source.beanDef.classPtr, injects)
+					for _, inject := range injects {
+						if err := ctx.injectConverted(inject, source, conv); err != nil {
+							return nil, err
+						}
+					}
+					continue
+				}
+				err = convErr
+			}
+			if len(requiredOnly(injects)) == 0 {
+				trace.printf("Skip optional '%v' in to %+v\n", ifaceType, injects)
+				continue
+			}
 			return nil, errors.Errorf("%v, required by those injections: %v", err, injects)
 		}
 
-		if Verbose {
-			fmt.Printf("Inject '%v' by implementation '%v' in to %+v\n", ifaceType, service.beanDef.classPtr, injects)
-		}
+		trace.printf("Inject '%v' by implementation '%v' in to %+v\n", ifaceType, service.beanDef.classPtr, injects)
 
 		for _, inject := range injects {
-			if err := inject.inject(service); err != nil {
+			if err := ctx.injectTraced(inject, service); err != nil {
 				return nil, err
 			}
 		}
 
-		beansByType[ifaceType] = service
-		name := ifaceType.String()
-		beansByName[name] = append(beansByName[name], service)
+		ctx.registry.addBean(ifaceType, service)
 	}
 
-	ctx := &context{
-		core:        core,
+	// named match
+	for _, inject := range named {
+		service, ok := names[inject.injectionDef.qualifier]
+		if !ok {
+			if inject.injectionDef.optional {
+				trace.printf("Skip optional '%s' in to %v\n", inject.injectionDef.qualifier, inject)
+				continue
+			}
+			return nil, errors.Errorf("can not find bean named '%s' required by %v", inject.injectionDef.qualifier, inject)
+		}
+		trace.printf("Inject '%s' by name '%v' in to %v\n", inject.injectionDef.qualifier, service.beanDef.classPtr, inject)
+		if err := ctx.injectTraced(inject, service); err != nil {
+			return nil, err
+		}
+	}
+
+	// environment variables
+	if conf.environment {
+		for _, b := range core {
+			if err := injectEnvs(b.valuePtr.Elem(), b.beanDef); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// registered interfaces
+	for _, m := range markers {
+		if _, ok := ctx.registry.findByType(m.ifaceType); ok {
+			continue
+		}
+		service, err := searchByInterface(m.ifaceType, core)
+		if err != nil && len(findCandidates(m.ifaceType, core)) == 0 {
+			if pb, ok := ctx.parentBean(m.ifaceType); ok {
+				service, err = pb, nil
+			}
+		}
+		if err != nil {
+			return nil, errors.Errorf("%v, required by registered interface", err)
+		}
+		trace.printf("Register '%v' by implementation '%v'\n", m.ifaceType, service.beanDef.classPtr)
+		ctx.registry.addBean(m.ifaceType, service)
 	}
-	ctx.registry.beansByName = beansByName
-	ctx.registry.beansByType = beansByType
 
-	return ctx, ctx.postConstruct()
+	// providers
+	for _, inject := range providers {
+		trace.printf("Inject provider of '%v' in to %v\n", inject.injectionDef.fieldType.Out(0), inject)
+		if err := inject.injectionDef.set(&inject.value, ctx.provider(inject.injectionDef.fieldType), conf.unexportedFields); err != nil {
+			return nil, err
+		}
+	}
+
+	// function factories
+	for _, f := range factories {
+		trace.printf("Factory of '%v' on position %d\n", f.factory.beanType, f.position)
+		if err := ctx.runFactory(f); err != nil {
+			return nil, err
+		}
+	}
+
+	// required modules
+	for _, m := range modules {
+		if err := m.checkRequires(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	// dependency cycles
+	if conf.cyclePolicy != AllowCycles {
+		if cycle := findCycle(core); cycle != nil {
+			if conf.cyclePolicy == Panic {
+				panic(fmt.Sprintf("dependency cycle %s", cycle))
+			}
+			return nil, errors.Errorf("dependency cycle %s", cycle)
+		}
+	}
+
+	// startup order
+	if conf.startupOrder {
+		for _, b := range core {
+			if b.beanDef.invalidOrder != nil {
+				return nil, b.beanDef.invalidOrder
+			}
+		}
+	}
+
+	// pipeline stage
+	if conf.pipelineStage {
+		if err := ctx.checkStage(conf.stage); err != nil {
+			return nil, err
+		}
+	}
+
+	initStart := time.Now()
+	initialized, err := ctx.postConstruct(goCtx)
+	if err == nil {
+		if e := ctx.applyMappers(); e != nil {
+			err = ctx.rollback(initialized, []error{e})
+		}
+	}
+	ctx.createdAt = time.Now()
+	ctx.initDuration = ctx.createdAt.Sub(initStart)
+	ctx.createDuration = ctx.createdAt.Sub(start)
+	if err != nil {
+		ctx.setPhase(PhaseFailed)
+		return ctx, err
+	}
+	if conf.warmup {
+		ctx.Warmup()
+	}
+	ctx.setPhase(PhaseReady)
+	ctx.notify(func(o ContextObserver) {
+		o.OnContextReady()
+	})
+	for _, hook := range conf.afterCreate {
+		hook(ctx, ctx.createDuration)
+	}
+	if conf.baseContext != nil {
+		go ctx.watchBaseContext(conf.baseContext)
+	}
+	return ctx, nil
+}
+
+/**
+	Injections that are not optional
+ */
+func requiredOnly(injects []*injection) []*injection {
+	var list []*injection
+	for _, inject := range injects {
+		if !inject.injectionDef.optional {
+			list = append(list, inject)
+		}
+	}
+	return list
 }
 
 func errorNoCandidates(pointers map[reflect.Type][]*injection) error {
@@ -187,7 +653,7 @@ func errorNoCandidates(pointers map[reflect.Type][]*injection) error {
 
 func (t *context) Core() []reflect.Type {
 	var list []reflect.Type
-	for typ, _ := range t.core {
+	for typ, _ := range t.coreBeans() {
 		list = append(list, typ)
 	}
 	return list
@@ -195,7 +661,7 @@ func (t *context) Core() []reflect.Type {
 
 func (t *context) Bean(typ reflect.Type) (interface{}, bool) {
 	if b, ok := t.getBean(typ); ok {
-		return b.obj, true
+		return b.exposed, true
 	} else {
 		return nil, false
 	}
@@ -209,59 +675,255 @@ func (t *context) MustBean(typ reflect.Type) interface{} {
 	}
 }
 
-func (t *context) Lookup(iface string) []interface{} {
+func (t *context) BeanFor(instance interface{}) (reflect.Type, bool) {
+	if instance == nil || !reflect.TypeOf(instance).Comparable() {
+		return nil, false
+	}
+	for classPtr, b := range t.coreBeans() {
+		if b.obj == instance || b.exposed == instance {
+			return classPtr, true
+		}
+	}
+	return nil, false
+}
+
+func (t *context) FindAll(ifaceType reflect.Type) BeanSlice {
+	var candidates []*bean
+	for _, b := range t.coreBeans() {
+		if b.beanDef.implements(ifaceType) {
+			candidates = append(candidates, b)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].position < candidates[j].position
+	})
+	var list BeanSlice
+	for _, b := range candidates {
+		list = append(list, b.exposed)
+	}
+	return list
+}
+
+func (t *context) BeanTypesImplementing(ifaceType reflect.Type) []reflect.Type {
+	return findCandidates(ifaceType, t.coreBeans())
+}
+
+func (t *context) Lookup(iface string) BeanSlice {
 	return t.registry.findByName(iface)
 }
 
-func (t *context) Inject(obj interface{}) error {
+func (t *context) SortedLookup(iface string, less func(a, b interface{}) bool) BeanSlice {
+	return t.Lookup(iface).Sort(less)
+}
+
+func (t *context) MustLookup(iface string) BeanSlice {
+	return mustLookup(t, iface)
+}
+
+func mustLookup(ctx Context, iface string) BeanSlice {
+	list := ctx.Lookup(iface)
+	if len(list) == 0 {
+		panic(fmt.Sprintf("no beans registered under name '%s'", iface))
+	}
+	return list
+}
+
+func (t *context) BeanNames() []string {
+	return t.registry.names()
+}
+
+func (t *context) LookupAllNames() map[string][]interface{} {
+	return t.registry.snapshotNames()
+}
+
+/**
+	Resolution of inject fields on runtime, implemented by the context and by the chain of contexts
+ */
+type injectionResolver interface {
+	findInjected(caller reflect.Type, inject *injectionDef) (*bean, bool)
+	provider(funcType reflect.Type) reflect.Value
+}
+
+func (t *context) Inject(obj interface{}) (injected []string, err error) {
+	return t.injectWith(t, obj)
+}
+
+/**
+	Inject fields of the object with options and hooks of this context, beans and providers are given by the resolver
+ */
+func (t *context) injectWith(resolver injectionResolver, obj interface{}) (injected []string, err error) {
+	if t.IsFrozen() {
+		return nil, ErrContextFrozen
+	}
 	if obj == nil {
-		return errors.New("null obj is are not allowed")
+		return nil, errors.New("null obj is are not allowed")
 	}
 	classPtr := reflect.TypeOf(obj)
 	if classPtr.Kind() != reflect.Ptr {
-		return errors.Errorf("non-pointer instances are not allowed, type %v", classPtr)
+		return nil, errors.Errorf("non-pointer instances are not allowed, type %v", classPtr)
 	}
 	valuePtr := reflect.ValueOf(obj)
 	value := valuePtr.Elem()
 	if bd, err := t.cache(obj, classPtr); err != nil {
-		return err
+		return nil, err
 	} else {
+		for _, hook := range t.conf.beforeInject {
+			hook(obj, runtimeDefinition(bd))
+		}
+		if err := injectProperties(value, bd, t.conf.propertySources); err != nil {
+			return nil, err
+		}
 		for _, inject := range bd.fields {
-			if impl, ok := t.getBean(inject.fieldType); ok {
-				if err := inject.inject(&value, impl); err != nil {
-					return err
+			if inject.fieldType.Kind() == reflect.Func {
+				if err := inject.set(&value, resolver.provider(inject.fieldType), t.conf.unexportedFields); err != nil {
+					return injected, err
+				}
+			} else if impl, ok := resolver.findInjected(classPtr, inject); ok {
+				path := []injectionStep{{classPtr, inject.fieldName}}
+				if err := t.checkInjected(impl, path); err != nil {
+					return injected, err
+				}
+				start := time.Now()
+				if err := inject.injectExposed(&value, impl, t.conf.unexportedFields); err != nil {
+					return injected, err
 				}
+				t.conf.metrics.RecordInjection(classPtr, impl.beanDef.classPtr, time.Since(start))
+				t.notify(func(o ContextObserver) {
+					o.OnBeanInjected(classPtr, impl.beanDef.classPtr, inject.fieldName)
+				})
+			} else if source, conv, ok := t.convertible(inject); ok {
+				if err := t.setConverted(&value, inject, source, conv); err != nil {
+					return injected, err
+				}
+			} else if inject.optional {
+				continue
 			} else {
-				return errors.Errorf("implementation not found for field '%s' with type '%v'",  inject.fieldName, inject.fieldType)
+				return injected, errors.Errorf("implementation not found for field '%s' with type '%v' in %v",  inject.fieldName, inject.fieldType, classPtr)
+			}
+			injected = append(injected, inject.fieldName)
+		}
+		if t.conf.environment {
+			if err := injectEnvs(value, bd); err != nil {
+				return injected, err
 			}
 		}
+		for _, hook := range t.conf.afterInject {
+			hook(obj, runtimeDefinition(bd), injected)
+		}
+	}
+	return injected, nil
+}
+
+/**
+	Find the bean for inject field by name if qualifier is set, otherwise by type
+ */
+func (t *context) findInjected(caller reflect.Type, inject *injectionDef) (*bean, bool) {
+	if inject.qualifier != "" {
+		return t.registry.findBeanByName(inject.qualifier)
 	}
-	return nil
+	return t.getBeanFor(caller, inject.fieldType)
 }
 
 // multi-threading safe
 func (t *context) getBean(ifaceType reflect.Type) (*bean, bool) {
+	return t.getBeanFor(nil, ifaceType)
+}
+
+/**
+	Same as getBean, caller is the class of the object injected on runtime, nil for direct access
+ */
+func (t *context) getBeanFor(caller, ifaceType reflect.Type) (*bean, bool) {
+	start := time.Now()
+	b, ok := t.findBean(ifaceType)
+	elapsed := time.Since(start)
+	t.conf.metrics.RecordBeanAccess(ifaceType, elapsed)
+	if t.conf.accessLog != nil {
+		t.conf.accessLog.LogAccess(caller, ifaceType, ok, elapsed)
+	}
+	return b, ok
+}
+
+func (t *context) findBean(ifaceType reflect.Type) (*bean, bool) {
+	core := t.coreBeans()
 	if b, ok := t.registry.findByType(ifaceType); ok {
 		return b, true
-	} else if b, ok := t.core[ifaceType]; ok {
+	} else if b, ok := core[ifaceType]; ok && reflect.TypeOf(b.exposed).AssignableTo(ifaceType) {
 		// pointer match with core
-		t.registry.addBean(ifaceType, b)
+		t.bindBean(ifaceType, b)
 		return b, true
 	} else {
-		b, err := searchByInterface(ifaceType, t.core)
+		b, err := searchByInterface(ifaceType, core)
+		if err != nil && len(findCandidates(ifaceType, core)) == 0 {
+			if pb, ok := t.providedBean(ifaceType); ok {
+				b, err = pb, nil
+			}
+		}
 		if err != nil {
+			if t.parent != nil && len(findCandidates(ifaceType, core)) == 0 {
+				return t.parentBean(ifaceType)
+			}
 			return nil, false
 		}
-		t.registry.addBean(ifaceType, b)
+		if !reflect.TypeOf(b.exposed).AssignableTo(ifaceType) {
+			return nil, false
+		}
+		t.bindBean(ifaceType, b)
 		return b, true
 	}
 }
 
+/**
+	Current beans of core, safe to iterate without locks because the map is never modified in place
+ */
+func (t *context) coreBeans() map[reflect.Type]*bean {
+	t.registry.RLock()
+	defer t.registry.RUnlock()
+	return t.core
+}
+
+/**
+	Cache the type found in core, skipped if the bean was replaced in core after the lookup
+ */
+func (t *context) bindBean(ifaceType reflect.Type, b *bean) {
+	t.registry.Lock()
+	defer t.registry.Unlock()
+	if current, ok := t.core[b.beanDef.classPtr]; ok && current != b {
+		return
+	}
+	t.registry.cache(ifaceType, b)
+}
+
+/**
+	Put the new bean instead of the old one in core and under all types and names in the registry.
+	The registry lock is held by the caller.
+ */
+func (t *context) replaceCore(old, b *bean) {
+	core := make(map[reflect.Type]*bean, len(t.core))
+	for classPtr, e := range t.core {
+		if e == old {
+			e = b
+		}
+		core[classPtr] = e
+	}
+	t.core = core
+	var types []reflect.Type
+	for ifaceType, e := range t.registry.beansByType {
+		if e == old {
+			types = append(types, ifaceType)
+		}
+	}
+	for _, ifaceType := range types {
+		t.registry.replace(ifaceType, old, b)
+	}
+}
+
 // multi-threading safe
 func (t *context) cache(instance interface{}, classPtr reflect.Type) (*beanDef, error) {
 	if bd, ok := t.runtimeCache.Load(classPtr); ok {
+		atomic.AddInt64(&t.cacheHits, 1)
 		return bd.(*beanDef), nil
 	} else {
+		atomic.AddInt64(&t.cacheMisses, 1)
 		b, err := investigate(instance, classPtr)
 		if err != nil {
 			return nil, err
@@ -271,33 +933,112 @@ func (t *context) cache(instance interface{}, classPtr reflect.Type) (*beanDef,
 	}
 }
 
-func (t *context) postConstruct() error {
-	var fallback []DisposableBean
+/**
+	Initialize beans of core, returns initialized disposable beans in order of initialization.
+	On error the initialized beans are destroyed.
+ */
+func (t *context) postConstruct(ctx stdcontext.Context) ([]DisposableBean, error) {
+	var initializedBeans []DisposableBean
 	var err []error
-	for _, instance := range t.core {
-		if b, ok := instance.obj.(InitializingBean); ok {
-			if e := b.PostConstruct(); e != nil {
-				err = append(err, e)
-			} else if d, ok := instance.obj.(DisposableBean); ok {
-				fallback = append(fallback, d)
-			}
+	// handlers without PostConstruct() are ready after wiring
+	for _, b := range t.coreBeans() {
+		switch b.obj.(type) {
+		case InitializingBean, ContextInitializingBean:
+		default:
+			t.activateHandler(b.obj)
+		}
+	}
+	for _, step := range initOrder(t.coreBeans(), t.conf.startupOrder) {
+		instance := step.bean
+		if e := ctx.Err(); e != nil {
+			err = append(err, errors.Wrap(e, "initialization interrupted"))
+			break
+		}
+		start := time.Now()
+		initialized, e := t.initBean(ctx, instance.obj)
+		if !initialized {
+			continue
+		}
+		t.record(instance.beanDef.classPtr, LifecyclePostConstruct, start, e)
+		if e != nil && t.handleError(LifecyclePostConstruct, e) {
+			continue
+		}
+		if e != nil {
+			err = append(err, step.wrap(e))
+		} else if d, ok := instance.obj.(DisposableBean); ok {
+			initializedBeans = append(initializedBeans, d)
 		}
 	}
 	if len(err) > 0 {
-		for _, d := range fallback {
-			if e := d.Destroy(); e != nil {
-				err = append(err, e)
-			}
+		return nil, t.rollback(initializedBeans, err)
+	}
+	return initializedBeans, nil
+}
+
+/**
+	Destroy initialized beans after failed creation of context, returns the cause with errors of destruction
+ */
+func (t *context) rollback(initialized []DisposableBean, err []error) error {
+	for _, d := range initialized {
+		if e := t.destroyBean(d); e != nil {
+			err = append(err, e)
 		}
 	}
 	return multiple(err)
 }
 
 func (t *context) Close() error {
+	t.closeOnce.Do(func() {
+		t.closeErr = t.closeContext()
+	})
+	return t.closeErr
+}
+
+func (t *context) closeContext() error {
+	t.setPhase(PhaseClosing)
+	defer t.closedOnce.Do(func() {
+		close(t.closed)
+		if p, ok := t.parent.(*context); ok {
+			p.release(t)
+		}
+	})
+	defer t.setPhase(PhaseClosed)
+	t.notify(func(o ContextObserver) {
+		o.OnContextClosing()
+	})
+	t.doneOnce.Do(func() {
+		close(t.done)
+	})
+	t.closeNotifyChannels()
+	if t.cloned {
+		return nil
+	}
+	err := t.destroyCore()
+	if e := t.destroyProvided(); len(e) > 0 {
+		if err != nil {
+			e = append([]error{err}, e...)
+		}
+		err = multiple(e)
+	}
+	if t.conf.closeParent && t.parent != nil {
+		if e := t.parent.Close(); e != nil {
+			if err == nil {
+				return e
+			}
+			return multiple([]error{err, e})
+		}
+	}
+	return err
+}
+
+func (t *context) destroyCore() error {
+	if t.conf.closeParallelism > 1 {
+		return t.closeParallel(t.conf.closeParallelism)
+	}
 	var err []error
-	for _, instance := range t.core {
+	for _, instance := range t.coreBeans() {
 		if c, ok := instance.obj.(DisposableBean); ok {
-			if e := c.Destroy(); e != nil {
+			if e := t.destroyBean(c); e != nil && !t.handleError(LifecycleDestroy, e) {
 				err = append(err, e)
 			}
 		}
@@ -319,6 +1060,10 @@ func multiple(err []error) error {
 func investigate(obj interface{}, classPtr reflect.Type) (*bean, error) {
 	var fields []*injectionDef
 	var notImplements []reflect.Type
+	var properties []*propertyDef
+	var envs []*envInjection
+	var order int
+	var invalidOrder error
 	valuePtr := reflect.ValueOf(obj)
 	class := classPtr.Elem()
 	for j := 0; j < class.NumField(); j++ {
@@ -326,39 +1071,67 @@ func investigate(obj interface{}, classPtr reflect.Type) (*bean, error) {
 		if field.Anonymous {
 			notImplements = append(notImplements, field.Type)
 		}
-		if field.Tag == "inject" {
+		if expr, ok := field.Tag.Lookup("order"); ok {
+			n, err := strconv.Atoi(expr)
+			if err != nil {
+				invalidOrder = errors.Errorf("invalid order '%s' on field '%s' in %v", expr, field.Name, classPtr)
+			}
+			order = n
+		}
+		expr, tagged := field.Tag.Lookup("inject")
+		if field.Tag == "inject" || tagged {
 			kind := field.Type.Kind()
-			if kind != reflect.Ptr && kind != reflect.Interface {
-				return nil, errors.Errorf("not a pointer or interface field type '%v' on position %d in %v", field.Type, j, classPtr)
+			if kind != reflect.Ptr && kind != reflect.Interface && !isProvider(field.Type) {
+				return nil, errors.Errorf("not a pointer, interface or provider field type '%v' on position %d in %v", field.Type, j, classPtr)
 			}
 			injectDef := &injectionDef {
 				class:     class,
 				fieldNum:  j,
 				fieldName: field.Name,
 				fieldType: field.Type,
+				fieldTag:  field.Tag,
+			}
+			if tagged {
+				if err := injectDef.parseTag(expr); err != nil {
+					return nil, errors.Errorf("%v on field '%s' in %v", err, field.Name, classPtr)
+				}
 			}
 			fields = append(fields, injectDef)
+		} else if expr, ok := field.Tag.Lookup("env"); ok {
+			envDef, err := parseEnv(class, j, field, expr)
+			if err != nil {
+				// reported only if environment variables are enabled
+				envDef = &envInjection{class: class, fieldNum: j, fieldName: field.Name, fieldType: field.Type, invalid: err}
+			}
+			envs = append(envs, envDef)
+		} else if expr, ok := field.Tag.Lookup("value"); ok {
+			property, err := parseProperty(class, j, field, expr)
+			if err != nil {
+				// reported only if property sources are given
+				property = &propertyDef{class: class, fieldNum: j, fieldName: field.Name, fieldType: field.Type, invalid: err}
+			}
+			properties = append(properties, property)
 		}
 	}
 	return &bean{
 		obj:           obj,
+		exposed:       obj,
 		valuePtr:      valuePtr,
 		beanDef:  &beanDef{
 			classPtr:      classPtr,
 			notImplements: notImplements,
 			fields:        fields,
+			properties:    properties,
+			envs:          envs,
+			order:         order,
+			invalidOrder:  invalidOrder,
 		},
 	}, nil
 }
 
 
 func searchByInterface(ifaceType reflect.Type, core map[reflect.Type]*bean) (*bean, error) {
-	var candidates []reflect.Type
-	for serviceTyp, service := range core {
-		if service.beanDef.implements(ifaceType) {
-			candidates = append(candidates, serviceTyp)
-		}
-	}
+	candidates := findCandidates(ifaceType, core)
 	switch len(candidates) {
 	case 0:
 		return nil, errors.Errorf("can not find implementations for '%v' interface", ifaceType)
@@ -200,7 +200,7 @@ func TestRequest(t *testing.T) {
 		requestParams: "username=Alex",
 	}
 
-	err = ctx.Inject(controller)
+	_, err = ctx.Inject(controller)
 	require.Nil(t, err)
 
 	controller.routeAddUser("alex")
@@ -287,7 +287,7 @@ func TestRequestMultithreading(t *testing.T) {
 			controller := &requestScope {
 				requestParams: fmt.Sprintf("firstName=Alex%d", i),
 			}
-			err = ctx.Inject(controller)
+			_, err := ctx.Inject(controller)
 			require.Nil(t, err)
 			username := fmt.Sprintf("user%d", i)
 			controller.routeAddUser(username)
@@ -296,4 +296,231 @@ func TestRequestMultithreading(t *testing.T) {
 
 	wg.Wait()
 
-}
\ No newline at end of file
+}
+func TestBeanNames(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+
+	ctx, err := context.Create(
+		logger,
+		&storageImpl{},
+		&configServiceImpl{},
+		&userServiceImpl{},
+		context.Interface((*UserService)(nil)),
+		context.Interface((*Storage)(nil)),
+		context.Interface((*ConfigService)(nil)),
+		&struct{ Config *configServiceImpl `inject` }{},
+	)
+	require.Nil(t, err)
+
+	require.Equal(t, []string{
+		"*context_test.configServiceImpl",
+		"*log.Logger",
+		"context_test.ConfigService",
+		"context_test.Storage",
+		"context_test.UserService",
+	}, ctx.BeanNames())
+
+}
+
+func TestBeanFor(t *testing.T) {
+
+	context.Verbose = false
+
+	storageInstance := &configStorage{}
+	ctx, err := context.Create(
+		storageInstance,
+		&configServiceImpl{},
+		&userServiceImpl{},
+	)
+	require.Nil(t, err)
+
+	configService := ctx.MustBean(ConfigServiceClass).(*configServiceImpl)
+	userService := ctx.MustBean(UserServiceClass).(*userServiceImpl)
+	require.Equal(t, Storage(storageInstance), configService.Storage)
+	require.Equal(t, Storage(storageInstance), userService.Storage)
+
+	typ, ok := ctx.BeanFor(userService.Storage)
+	require.True(t, ok)
+	require.Equal(t, reflect.TypeOf(storageInstance), typ)
+	require.Contains(t, ctx.Core(), typ)
+
+	_, ok = ctx.BeanFor(&configStorage{})
+	require.False(t, ok)
+
+	_, ok = ctx.BeanFor(nil)
+	require.False(t, ok)
+
+}
+
+type optionalCache interface {
+	Get(key string) string
+}
+
+type optionalHandler struct {
+	Storage       Storage       `inject`
+	Cache         optionalCache `inject:"optional"`
+	ConfigService ConfigService `inject`
+}
+
+type optionalPointerHolder struct {
+	Logger *log.Logger `inject:"optional"`
+}
+
+func TestInjectOptional(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create(
+		&configStorage{},
+		&configServiceImpl{},
+		&optionalPointerHolder{},
+		&struct{ Cache optionalCache `inject:"optional"` }{},
+	)
+	require.Nil(t, err)
+
+	holder := ctx.MustBean(reflect.TypeOf(&optionalPointerHolder{})).(*optionalPointerHolder)
+	require.Nil(t, holder.Logger)
+
+	handler := &optionalHandler{}
+	injected, err := ctx.Inject(handler)
+	require.Nil(t, err)
+	require.Equal(t, []string{"Storage", "ConfigService"}, injected)
+	require.Nil(t, handler.Cache)
+	require.NotNil(t, handler.ConfigService)
+
+	_, err = context.Create(&struct{ Storage Storage `inject:"storage,config"` }{})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "invalid inject tag")
+
+}
+
+func TestLookupAllNames(t *testing.T) {
+
+	context.Verbose = false
+
+	storage := &configStorage{}
+	ctx, err := context.Create(
+		storage,
+		&configServiceImpl{},
+		context.Interface((*Storage)(nil)),
+		context.Interface((*ConfigService)(nil)),
+	)
+	require.Nil(t, err)
+
+	names := ctx.LookupAllNames()
+	require.Equal(t, len(ctx.BeanNames()), len(names))
+	for _, name := range ctx.BeanNames() {
+		require.Equal(t, ctx.Lookup(name), context.BeanSlice(names[name]))
+	}
+	require.Equal(t, []interface{}{storage}, names["context_test.Storage"])
+
+	names["context_test.Storage"][0] = &memoryStorage{}
+	names["context_test.Storage"] = nil
+	delete(names, "context_test.ConfigService")
+	names["extra"] = []interface{}{storage}
+
+	require.Equal(t, context.BeanSlice{storage}, ctx.Lookup("context_test.Storage"))
+	require.Equal(t, 1, len(ctx.Lookup("context_test.ConfigService")))
+	require.Empty(t, ctx.Lookup("extra"))
+
+}
+
+type lruCache struct{}
+
+func (t *lruCache) Get(key string) string { return "lru" }
+
+type diskCache struct{}
+
+func (t *diskCache) Get(key string) string { return "disk" }
+
+type nearCache struct{}
+
+func (t *nearCache) Get(key string) string { return "near" }
+
+func TestBeanTypesImplementing(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create(
+		&lruCache{},
+		&nearCache{},
+		&diskCache{},
+		&configStorage{},
+	)
+	require.Nil(t, err)
+
+	cacheClass := reflect.TypeOf((*optionalCache)(nil)).Elem()
+	require.Equal(t, []reflect.Type{
+		reflect.TypeOf(&diskCache{}),
+		reflect.TypeOf(&lruCache{}),
+		reflect.TypeOf(&nearCache{}),
+	}, ctx.BeanTypesImplementing(cacheClass))
+
+	require.Empty(t, ctx.BeanTypesImplementing(UserServiceClass))
+
+}
+
+func TestMustLookup(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create(&configStorage{}, context.Interface((*Storage)(nil)))
+	require.Nil(t, err)
+
+	require.Equal(t, ctx.Lookup("context_test.Storage"), ctx.MustLookup("context_test.Storage"))
+
+	require.PanicsWithValue(t, "no beans registered under name 'context_test.UserService'", func() {
+		ctx.MustLookup("context_test.UserService")
+	})
+
+}
+
+type middleware interface {
+	Priority() int
+}
+
+var middlewareClass = reflect.TypeOf((*middleware)(nil)).Elem()
+
+type authMiddleware struct{}
+
+func (t *authMiddleware) Priority() int { return 10 }
+
+type loggingMiddleware struct{}
+
+func (t *loggingMiddleware) Priority() int { return 1 }
+
+type metricsMiddleware struct{}
+
+func (t *metricsMiddleware) Priority() int { return 5 }
+
+type middlewareChain struct {
+	Auth    *authMiddleware    `inject`
+	Logging *loggingMiddleware `inject`
+	Metrics *metricsMiddleware `inject`
+}
+
+func TestSortedLookup(t *testing.T) {
+
+	context.Verbose = false
+
+	auth, logging, metrics := &authMiddleware{}, &loggingMiddleware{}, &metricsMiddleware{}
+	ctx, err := context.CreateWithOptions([]interface{}{auth, logging, metrics, &middlewareChain{}},
+		context.WithBeanNameStrategy(func(typ reflect.Type) string {
+			if typ.Implements(middlewareClass) {
+				return "middleware"
+			}
+			return typ.String()
+		}))
+	require.Nil(t, err)
+	require.Equal(t, 3, len(ctx.Lookup("middleware")))
+
+	sorted := ctx.SortedLookup("middleware", func(a, b interface{}) bool {
+		return a.(middleware).Priority() < b.(middleware).Priority()
+	})
+	require.Equal(t, context.BeanSlice{logging, metrics, auth}, sorted)
+
+	require.Empty(t, ctx.SortedLookup("unknown", func(a, b interface{}) bool { return false }))
+
+}
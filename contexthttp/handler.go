@@ -0,0 +1,122 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package contexthttp
+
+import (
+	"encoding/json"
+	"github.com/consensusdb/context"
+	"net/http"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type statsView struct {
+	Beans          int    `json:"beans"`
+	Bindings       int    `json:"bindings"`
+	CreatedAt      string `json:"createdAt"`
+	CreateDuration string `json:"createDuration"`
+	InitDuration   string `json:"initDuration"`
+}
+
+type beanView struct {
+	Type         string   `json:"type"`
+	Interfaces   []string `json:"interfaces"`
+	Dependencies []string `json:"dependencies"`
+}
+
+type contextView struct {
+	Phase string     `json:"phase"`
+	Stats statsView  `json:"stats"`
+	Beans []beanView `json:"beans"`
+}
+
+/**
+	Diagnostics handler of the context, usually mounted on /debug/context
+
+	Query parameter 'type' filters beans by class or by registered interface name.
+
+	Example:
+		http.Handle("/debug/context", contexthttp.ContextHandler(ctx))
+
+		curl http://localhost:8080/debug/context?type=app.UserService
+ */
+
+func ContextHandler(ctx context.Context) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		filter := r.URL.Query().Get("type")
+
+		interfaces := make(map[string][]string)
+		for _, b := range ctx.Bindings() {
+			impl := b.ImplType.String()
+			if b.InterfaceType != b.ImplType {
+				interfaces[impl] = append(interfaces[impl], b.InterfaceType.String())
+			}
+		}
+
+		stats := ctx.Stats()
+		view := contextView{
+			Phase: stats.Phase.String(),
+			Stats: statsView{
+				Beans:          stats.Beans,
+				Bindings:       stats.Bindings,
+				CreatedAt:      stats.CreatedAt.String(),
+				CreateDuration: stats.CreateDuration.String(),
+				InitDuration:   stats.InitDuration.String(),
+			},
+			Beans: []beanView{},
+		}
+
+		for _, node := range ctx.Graph().Nodes {
+			bean := beanView{
+				Type:         node.Type.String(),
+				Interfaces:   interfaces[node.Type.String()],
+				Dependencies: []string{},
+			}
+			if bean.Interfaces == nil {
+				bean.Interfaces = []string{}
+			}
+			for _, d := range node.Dependencies {
+				bean.Dependencies = append(bean.Dependencies, d.String())
+			}
+			if filter == "" || matches(bean, filter) {
+				view.Beans = append(view.Beans, bean)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(view); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+func matches(bean beanView, filter string) bool {
+	if bean.Type == filter {
+		return true
+	}
+	for _, iface := range bean.Interfaces {
+		if iface == filter {
+			return true
+		}
+	}
+	return false
+}
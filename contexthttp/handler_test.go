@@ -0,0 +1,93 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package contexthttp_test
+
+import (
+	"encoding/json"
+	"github.com/consensusdb/context"
+	"github.com/consensusdb/context/contexthttp"
+	"github.com/stretchr/testify/require"
+	"net/http/httptest"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type Repository interface {
+	Find(id string) string
+}
+
+type repositoryImpl struct {
+}
+
+func (t *repositoryImpl) Find(id string) string {
+	return id
+}
+
+type serviceImpl struct {
+	Repository `inject`
+}
+
+func createContext(t *testing.T) context.Context {
+	context.Verbose = false
+	ctx, err := context.Create(&repositoryImpl{}, &serviceImpl{})
+	require.Nil(t, err)
+	return ctx
+}
+
+func TestContextHandler(t *testing.T) {
+
+	handler := contexthttp.ContextHandler(createContext(t))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/debug/context", nil))
+	require.Equal(t, 200, w.Code)
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var view map[string]interface{}
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), &view))
+	require.Equal(t, "ready", view["phase"])
+	require.NotNil(t, view["stats"])
+
+	var types []string
+	for _, b := range view["beans"].([]interface{}) {
+		types = append(types, b.(map[string]interface{})["type"].(string))
+	}
+	require.Equal(t, []string{"*contexthttp_test.repositoryImpl", "*contexthttp_test.serviceImpl"}, types)
+
+}
+
+func TestContextHandlerFilter(t *testing.T) {
+
+	handler := contexthttp.ContextHandler(createContext(t))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/debug/context?type=contexthttp_test.Repository", nil))
+	require.Equal(t, 200, w.Code)
+
+	var view map[string]interface{}
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), &view))
+
+	beans := view["beans"].([]interface{})
+	require.Equal(t, 1, len(beans))
+	require.Equal(t, "*contexthttp_test.repositoryImpl", beans[0].(map[string]interface{})["type"])
+
+}
@@ -0,0 +1,69 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+//go:build linux || darwin
+
+package contextplugin
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/pkg/errors"
+	"plugin"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Name of the symbol exported by plugin, function or variable of type func() []interface{}
+ */
+const BeansSymbol = "ContextBeans"
+
+/**
+	Load Go plugin from the .so file, call its ContextBeans function and register returned beans by Provide().
+
+	Example of the plugin:
+		package main
+
+		func ContextBeans() []interface{} {
+			return []interface{}{ &greeter{} }
+		}
+
+	Build the plugin with 'go build -buildmode=plugin' with the same version of this module as the application.
+ */
+func ProvidePlugin(ctx context.Context, pluginPath string) error {
+	p, err := plugin.Open(pluginPath)
+	if err != nil {
+		return errors.Wrapf(err, "open plugin '%s'", pluginPath)
+	}
+	sym, err := p.Lookup(BeansSymbol)
+	if err != nil {
+		return errors.Wrapf(err, "plugin '%s'", pluginPath)
+	}
+	var beans func() []interface{}
+	switch fn := sym.(type) {
+	case func() []interface{}:
+		beans = fn
+	case *func() []interface{}:
+		beans = *fn
+	default:
+		return errors.Errorf("symbol '%s' of plugin '%s' has type '%T', expected func() []interface{}", BeansSymbol, pluginPath, sym)
+	}
+	return ctx.Provide(beans()...)
+}
@@ -0,0 +1,87 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+//go:build linux || darwin
+
+package contextplugin_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/consensusdb/context/contextplugin"
+	"github.com/stretchr/testify/require"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type Greeter interface {
+	Greet(name string) string
+}
+
+var GreeterClass = reflect.TypeOf((*Greeter)(nil)).Elem()
+
+var pluginPath string
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "contextplugin")
+	if err != nil {
+		log.Fatal(err)
+	}
+	pluginPath = filepath.Join(dir, "greeter.so")
+	args := append([]string{"build", "-buildmode=plugin"}, pluginBuildFlags...)
+	build := exec.Command("go", append(args, "-o", pluginPath, "./testdata/greeter")...)
+	build.Stderr = os.Stderr
+	if err := build.Run(); err != nil {
+		log.Printf("skip plugin tests, build failed: %v", err)
+		pluginPath = ""
+	}
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func TestProvidePlugin(t *testing.T) {
+
+	if pluginPath == "" {
+		t.Skip("plugin is not built")
+	}
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "plugin: ", log.LstdFlags)
+
+	ctx, err := context.Create(logger)
+	require.Nil(t, err)
+	defer ctx.Close()
+
+	require.Nil(t, contextplugin.ProvidePlugin(ctx, pluginPath))
+
+	greeter, ok := ctx.Bean(GreeterClass)
+	require.True(t, ok)
+	require.Equal(t, "Hello, Alex", greeter.(Greeter).Greet("Alex"))
+
+	err = contextplugin.ProvidePlugin(ctx, filepath.Join(filepath.Dir(pluginPath), "missing.so"))
+	require.NotNil(t, err)
+
+}
@@ -0,0 +1,51 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"log"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type greeter struct {
+	Logger *log.Logger `inject`
+	initialized bool
+}
+
+func (t *greeter) PostConstruct() error {
+	t.initialized = true
+	return nil
+}
+
+func (t *greeter) Greet(name string) string {
+	if !t.initialized {
+		return ""
+	}
+	return "Hello, " + name
+}
+
+func ContextBeans() []interface{} {
+	return []interface{}{ &greeter{} }
+}
+
+func main() {
+}
@@ -0,0 +1,71 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package contextprometheus
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/prometheus/client_golang/prometheus"
+	"reflect"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type prometheusMetrics struct {
+	beanAccess *prometheus.HistogramVec
+	injection  *prometheus.HistogramVec
+}
+
+/**
+	Metrics of the context exported as histograms 'context_bean_access_seconds' labeled by type
+	and 'context_injection_seconds' labeled by target and source types.
+	Panics if metrics are already registered in the registry.
+
+	Example:
+		ctx, err := context.CreateWithOptions(beans,
+			context.WithMetrics(contextprometheus.PrometheusMetrics(prometheus.DefaultRegisterer)))
+ */
+func PrometheusMetrics(registry prometheus.Registerer) context.Metrics {
+	t := &prometheusMetrics{
+		beanAccess: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "context",
+			Name:      "bean_access_seconds",
+			Help:      "Duration of lookup of the bean by type.",
+			Buckets:   prometheus.ExponentialBuckets(1e-7, 10, 7),
+		}, []string{"type"}),
+		injection: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "context",
+			Name:      "injection_seconds",
+			Help:      "Duration of injection of the source bean in to the target.",
+			Buckets:   prometheus.ExponentialBuckets(1e-7, 10, 7),
+		}, []string{"target", "source"}),
+	}
+	registry.MustRegister(t.beanAccess, t.injection)
+	return t
+}
+
+func (t *prometheusMetrics) RecordBeanAccess(typ reflect.Type, duration time.Duration) {
+	t.beanAccess.WithLabelValues(typ.String()).Observe(duration.Seconds())
+}
+
+func (t *prometheusMetrics) RecordInjection(target, source reflect.Type, duration time.Duration) {
+	t.injection.WithLabelValues(target.String(), source.String()).Observe(duration.Seconds())
+}
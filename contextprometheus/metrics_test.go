@@ -0,0 +1,79 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package contextprometheus_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/consensusdb/context/contextprometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type Repository interface {
+	Find(id string) string
+}
+
+type repositoryImpl struct {
+}
+
+func (t *repositoryImpl) Find(id string) string {
+	return id
+}
+
+type serviceImpl struct {
+	Repository `inject`
+}
+
+func TestPrometheusMetrics(t *testing.T) {
+
+	context.Verbose = false
+
+	registry := prometheus.NewRegistry()
+	ctx, err := context.CreateWithOptions(
+		[]interface{}{&repositoryImpl{}, &serviceImpl{}},
+		context.WithMetrics(contextprometheus.PrometheusMetrics(registry)),
+	)
+	require.Nil(t, err)
+
+	_, ok := ctx.Bean(reflect.TypeOf((*Repository)(nil)).Elem())
+	require.True(t, ok)
+
+	families, err := registry.Gather()
+	require.Nil(t, err)
+
+	counts := make(map[string]uint64)
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			counts[f.GetName()] += m.GetHistogram().GetSampleCount()
+		}
+	}
+	require.Equal(t, uint64(1), counts["context_injection_seconds"])
+	require.Equal(t, uint64(1), counts["context_bean_access_seconds"])
+
+	require.Panics(t, func() {
+		contextprometheus.PrometheusMetrics(registry)
+	})
+
+}
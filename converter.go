@@ -0,0 +1,122 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"github.com/pkg/errors"
+	"reflect"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Converter of beans to the types of inject fields that they do not implement, see WithTypeConverter
+ */
+type TypeConverter interface {
+
+	/**
+		Returns true if the bean of type 'from' could be converted to the interface 'to'
+	 */
+	CanConvert(from, to reflect.Type) bool
+
+	/**
+		Convert the bean to the value assignable to 'to'
+	 */
+	Convert(value interface{}, to reflect.Type) (interface{}, error)
+}
+
+/**
+	Consult the converter if no bean in core implements the interface of the inject field,
+	beans implementing the interface are injected without conversion.
+	Converters are tried in the order of registration, candidates are the beans that converter accepts.
+
+	Example:
+		context.WithTypeConverter(redisCacheConverter{})
+ */
+func WithTypeConverter(conv TypeConverter) Option {
+	return func(conf *contextConfig) {
+		conf.converters = append(conf.converters, conv)
+	}
+}
+
+/**
+	Find the single bean in core that one of converters could convert to the type
+ */
+func (t *context) findConvertible(typ reflect.Type) (*bean, TypeConverter, error) {
+	for _, conv := range t.conf.converters {
+		var candidates []*bean
+		for classPtr, b := range t.coreBeans() {
+			if conv.CanConvert(classPtr, typ) {
+				candidates = append(candidates, b)
+			}
+		}
+		switch len(candidates) {
+		case 0:
+			continue
+		case 1:
+			return candidates[0], conv, nil
+		default:
+			sortBeans(candidates)
+			var types []reflect.Type
+			for _, b := range candidates {
+				types = append(types, b.beanDef.classPtr)
+			}
+			return nil, nil, errors.Errorf("converter '%T' found two or more beans for '%v', candidates=%v", conv, typ, types)
+		}
+	}
+	return nil, nil, errors.Errorf("can not find implementations or convertible beans for '%v' interface", typ)
+}
+
+/**
+	Find convertible bean for the interface field on runtime
+ */
+func (t *context) convertible(inject *injectionDef) (*bean, TypeConverter, bool) {
+	if len(t.conf.converters) == 0 || inject.qualifier != "" || inject.fieldType.Kind() != reflect.Interface {
+		return nil, nil, false
+	}
+	source, conv, err := t.findConvertible(inject.fieldType)
+	return source, conv, err == nil
+}
+
+/**
+	Inject the converted bean, the dependency is registered on the source bean
+ */
+func (t *context) injectConverted(inject *injection, source *bean, conv TypeConverter) error {
+	start := time.Now()
+	err := t.setConverted(&inject.value, inject.injectionDef, source, conv)
+	if err == nil {
+		inject.owner.addDependency(source)
+	}
+	t.record(inject.owner.beanDef.classPtr, TraceInject, start, err)
+	return err
+}
+
+func (t *context) setConverted(value *reflect.Value, inject *injectionDef, source *bean, conv TypeConverter) error {
+	converted, err := conv.Convert(source.exposed, inject.fieldType)
+	if err != nil {
+		return errors.Wrapf(err, "convert bean '%v' for field '%s' in class '%v'", source.beanDef.classPtr, inject.fieldName, inject.class)
+	}
+	if converted == nil || !reflect.TypeOf(converted).AssignableTo(inject.fieldType) {
+		return errors.Errorf("converted bean '%T' is not assignable to field '%s' with type '%v' in class '%v'", converted, inject.fieldName, inject.fieldType, inject.class)
+	}
+	return inject.set(value, reflect.ValueOf(converted), t.conf.unexportedFields)
+}
@@ -0,0 +1,92 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type rawStorage struct {
+	data map[string]string
+}
+
+func (t *rawStorage) Get(key string) string {
+	return t.data[key]
+}
+
+func (t *rawStorage) Put(key, value string) {
+	t.data[key] = value
+}
+
+type rawStorageAdapter struct {
+	raw *rawStorage
+}
+
+func (t *rawStorageAdapter) Load(key string) string {
+	return t.raw.Get(key)
+}
+
+func (t *rawStorageAdapter) Store(key, value string) {
+	t.raw.Put(key, value)
+}
+
+type rawStorageConverter struct{}
+
+func (rawStorageConverter) CanConvert(from, to reflect.Type) bool {
+	return from == reflect.TypeOf(&rawStorage{}) && to == StorageClass
+}
+
+func (rawStorageConverter) Convert(value interface{}, to reflect.Type) (interface{}, error) {
+	return &rawStorageAdapter{raw: value.(*rawStorage)}, nil
+}
+
+func TestTypeConverter(t *testing.T) {
+
+	context.Verbose = false
+
+	raw := &rawStorage{data: map[string]string{"key": "value"}}
+	config := &configServiceImpl{}
+
+	ctx, err := context.CreateWithOptions(
+		[]interface{}{raw, config},
+		context.WithTypeConverter(rawStorageConverter{}),
+	)
+	require.Nil(t, err)
+
+	adapter, ok := config.Storage.(*rawStorageAdapter)
+	require.True(t, ok)
+	require.Equal(t, raw, adapter.raw)
+	require.Equal(t, "value", config.Load("key"))
+
+	handler := &struct{ Storage Storage `inject` }{}
+	_, err = ctx.Inject(handler)
+	require.Nil(t, err)
+	require.Equal(t, "value", handler.Storage.Load("key"))
+
+	_, err = context.Create(raw, &configServiceImpl{})
+	require.NotNil(t, err)
+
+}
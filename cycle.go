@@ -0,0 +1,132 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"reflect"
+	"strings"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Policy of handling dependency cycles between beans, like A->B->A
+ */
+
+type CircularDependencyPolicy int
+
+const (
+	/**
+		Allow dependency cycles, default. All beans are instantiated before injection of fields,
+		so each bean in the cycle receives the instance of the other one,
+		but PostConstruct() of some bean in the cycle is called before its dependency is initialized.
+	 */
+	AllowCycles CircularDependencyPolicy = iota
+
+	/**
+		Return error on dependency cycle
+	 */
+	ErrorOnCycle
+
+	/**
+		Panic on dependency cycle
+	 */
+	Panic
+)
+
+/**
+	Check dependency cycles between beans in core on creation of context, cycles are allowed by default
+ */
+func WithCircularDependencyPolicy(policy CircularDependencyPolicy) Option {
+	return func(conf *contextConfig) {
+		conf.cyclePolicy = policy
+	}
+}
+
+/**
+	Chain of beans in dependency cycle, the first bean is repeated at the end
+ */
+type dependencyCycle []*bean
+
+func (t dependencyCycle) String() string {
+	var out strings.Builder
+	for i, b := range t {
+		if i > 0 {
+			out.WriteString(" -> ")
+		}
+		out.WriteString(b.beanDef.classPtr.String())
+	}
+	return out.String()
+}
+
+/**
+	Find the first dependency cycle between beans in core, nil if there are no cycles
+ */
+func findCycle(core map[reflect.Type]*bean) dependencyCycle {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	var all []*bean
+	for _, b := range core {
+		all = append(all, b)
+	}
+	sortBeans(all)
+
+	state := make(map[*bean]int)
+	var path []*bean
+	var visit func(b *bean) dependencyCycle
+	visit = func(b *bean) dependencyCycle {
+		switch state[b] {
+		case visited:
+			return nil
+		case visiting:
+			for i, p := range path {
+				if p == b {
+					return append(append(dependencyCycle(nil), path[i:]...), b)
+				}
+			}
+		}
+		state[b] = visiting
+		path = append(path, b)
+		deps := append([]*bean(nil), b.dependencies...)
+		sortBeans(deps)
+		for _, d := range deps {
+			// skip beans of the parent context
+			if core[d.beanDef.classPtr] != d {
+				continue
+			}
+			if cycle := visit(d); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[b] = visited
+		return nil
+	}
+
+	for _, b := range all {
+		if cycle := visit(b); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type cycleA struct {
+	B *cycleB `inject`
+}
+
+type cycleB struct {
+	A *cycleA `inject`
+}
+
+func TestCircularDependencyError(t *testing.T) {
+
+	context.Verbose = false
+
+	_, err := context.CreateWithOptions([]interface{}{&cycleA{}, &cycleB{}}, context.WithCircularDependencyPolicy(context.ErrorOnCycle))
+	require.NotNil(t, err)
+	require.Equal(t, "dependency cycle *context_test.cycleA -> *context_test.cycleB -> *context_test.cycleA", err.Error())
+
+}
+
+func TestCircularDependencyAllowed(t *testing.T) {
+
+	context.Verbose = false
+	a, b := &cycleA{}, &cycleB{}
+
+	_, err := context.Create(a, b)
+	require.Nil(t, err)
+	require.True(t, b == a.B)
+	require.True(t, a == b.A)
+
+	a, b = &cycleA{}, &cycleB{}
+	_, err = context.CreateWithOptions([]interface{}{a, b}, context.WithCircularDependencyPolicy(context.AllowCycles))
+	require.Nil(t, err)
+	require.True(t, b == a.B)
+
+}
+
+func TestCircularDependencyPanic(t *testing.T) {
+
+	context.Verbose = false
+
+	require.Panics(t, func() {
+		context.CreateWithOptions([]interface{}{&cycleA{}, &cycleB{}}, context.WithCircularDependencyPolicy(context.Panic))
+	})
+
+}
@@ -0,0 +1,67 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Wiring trace of Create(), printed if Verbose and captured if WithDebugOnFailure
+ */
+type tracer struct {
+	verbose bool
+	buffer  *strings.Builder
+}
+
+func newTracer(conf *contextConfig) *tracer {
+	t := &tracer{verbose: conf.verbose}
+	if conf.debugOnFailure {
+		t.buffer = new(strings.Builder)
+	}
+	return t
+}
+
+func (t *tracer) printf(format string, args ...interface{}) {
+	if t.verbose {
+		fmt.Printf(format, args...)
+	}
+	if t.buffer != nil {
+		fmt.Fprintf(t.buffer, format, args...)
+	}
+}
+
+/**
+	Dump captured trace and the error of creation to os.Stderr
+ */
+func (t *tracer) dump(err error) {
+	if t.buffer != nil {
+		fmt.Fprintf(os.Stderr, "%sError %v\n", t.buffer.String(), err)
+	}
+}
+
+func (t *context) Debug() bool {
+	return t.conf.debugOnFailure
+}
@@ -0,0 +1,94 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type traceA struct{}
+type traceB struct{}
+type traceC struct{}
+type traceD struct{}
+type traceMissing struct{}
+
+type traceConsumer struct {
+	A       *traceA       `inject`
+	B       *traceB       `inject`
+	C       *traceC       `inject`
+	D       *traceD       `inject`
+	Missing *traceMissing `inject`
+}
+
+func captureStderr(t *testing.T, fn func()) string {
+	r, w, err := os.Pipe()
+	require.Nil(t, err)
+	stderr := os.Stderr
+	os.Stderr = w
+	fn()
+	os.Stderr = stderr
+	w.Close()
+	out, err := io.ReadAll(r)
+	require.Nil(t, err)
+	return string(out)
+}
+
+func TestDebugOnFailure(t *testing.T) {
+
+	context.Verbose = false
+	scan := []interface{}{&traceA{}, &traceB{}, &traceC{}, &traceD{}, &traceConsumer{}}
+
+	var err error
+	out := captureStderr(t, func() {
+		_, err = context.CreateWithOptions(scan, context.WithDebugOnFailure())
+	})
+	require.NotNil(t, err)
+
+	require.Equal(t, 4, strings.Count(out, "by pointer"))
+	for _, name := range []string{"traceA", "traceB", "traceC", "traceD"} {
+		require.Contains(t, out, "Inject '*context_test."+name+"' by pointer")
+	}
+	require.Contains(t, out, "Error "+err.Error())
+
+}
+
+func TestDebugOnSuccess(t *testing.T) {
+
+	context.Verbose = false
+	scan := []interface{}{&traceA{}, &traceB{}, &traceC{}, &traceD{}, &traceMissing{}, &traceConsumer{}}
+
+	var ctx context.Context
+	var err error
+	out := captureStderr(t, func() {
+		ctx, err = context.CreateWithOptions(scan, context.WithDebugOnFailure())
+	})
+	require.Nil(t, err)
+	require.True(t, ctx.Debug())
+	require.Equal(t, "", out)
+
+}
@@ -0,0 +1,75 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"github.com/pkg/errors"
+	"reflect"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Wrap the bean of type T by decorator and register the result instead of it.
+	Decorated bean is returned by Bean(), Lookup() and injected on runtime,
+	injections made on creation of context and lifecycle keep the original instance.
+
+	Example:
+		err := context.Decorate[Storage](ctx, func(s Storage) Storage {
+			return &cachingStorage{Storage: s}
+		})
+ */
+func Decorate[T any](ctx Context, decorator func(T) T) error {
+	typ := TokenOf[T]().Type()
+	c, ok := ctx.(*context)
+	if !ok {
+		return errors.Errorf("context '%T' does not support decoration of '%v'", ctx, typ)
+	}
+	return c.decorate(typ, func(obj interface{}) interface{} {
+		return decorator(obj.(T))
+	})
+}
+
+func (t *context) decorate(typ reflect.Type, decorator func(interface{}) interface{}) error {
+	if t.IsFrozen() {
+		return ErrContextFrozen
+	}
+	b, ok := t.getBean(typ)
+	if !ok {
+		return errors.Errorf("bean '%v' not found", typ)
+	}
+	decorated := decorator(b.exposed)
+	if decorated == nil {
+		return errors.Errorf("decorator returned nil for bean '%v'", typ)
+	}
+	if !reflect.TypeOf(decorated).AssignableTo(typ) {
+		return errors.Errorf("decorated bean of type '%T' does not implement '%v'", decorated, typ)
+	}
+	t.registry.replaceBean(typ, b, &bean{
+		obj:          b.obj,
+		exposed:      decorated,
+		valuePtr:     b.valuePtr,
+		beanDef:      b.beanDef,
+		dependencies: b.dependencies,
+		metadata:     b.metadata,
+	})
+	return nil
+}
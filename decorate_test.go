@@ -0,0 +1,102 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"log"
+	"os"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type cachingStorage struct {
+	Storage
+	cache map[string]string
+	hits  int
+}
+
+func (t *cachingStorage) Load(key string) string {
+	if value, ok := t.cache[key]; ok {
+		t.hits++
+		return value
+	}
+	value := t.Storage.Load(key)
+	t.cache[key] = value
+	return value
+}
+
+func TestDecorate(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+	storage := &storageImpl{}
+
+	ctx, err := context.Create(logger, storage, context.Interface((*Storage)(nil)))
+	require.Nil(t, err)
+	storage.Store("key", "value")
+
+	var caching *cachingStorage
+	err = context.Decorate[Storage](ctx, func(s Storage) Storage {
+		caching = &cachingStorage{Storage: s, cache: make(map[string]string)}
+		return caching
+	})
+	require.Nil(t, err)
+
+	decorated := ctx.MustBean(StorageClass).(Storage)
+	require.Equal(t, caching, decorated)
+	require.Equal(t, caching, ctx.Lookup("context_test.Storage")[0])
+
+	require.Equal(t, "value", decorated.Load("key"))
+	require.Equal(t, "value", decorated.Load("key"))
+	require.Equal(t, "value", decorated.Load("key"))
+	require.Equal(t, 2, caching.hits)
+
+	controller := &struct{ Storage `inject` }{}
+	_, err = ctx.Inject(controller)
+	require.Nil(t, err)
+	require.Equal(t, caching, controller.Storage)
+
+}
+
+func TestDecorateErrors(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create()
+	require.Nil(t, err)
+
+	err = context.Decorate[Storage](ctx, func(s Storage) Storage {
+		return s
+	})
+	require.NotNil(t, err)
+
+	ctx, err = context.Create(&configStorage{})
+	require.Nil(t, err)
+
+	err = context.Decorate[Storage](ctx, func(s Storage) Storage {
+		return nil
+	})
+	require.NotNil(t, err)
+
+}
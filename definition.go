@@ -0,0 +1,143 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"reflect"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Exported description of the bean for introspection by external tools
+ */
+
+type BeanDefinition struct {
+
+	/**
+		Class of the bean
+	 */
+	ClassType             reflect.Type
+
+	/**
+		Interfaces registered in context with this bean as implementation, sorted by name
+	 */
+	InterfacesImplemented []reflect.Type
+
+	/**
+		Inject fields of the bean
+	 */
+	Fields                []FieldDefinition
+
+	/**
+		All beans in core are singletons
+	 */
+	IsSingleton           bool
+
+	/**
+		All beans in core are created eagerly
+	 */
+	IsLazy                bool
+
+	/**
+		Initialization priority from 'order' tag
+	 */
+	Order                 int
+
+	/**
+		Metadata of the bean from MetadataProvider
+	 */
+	Metadata              map[string]string
+}
+
+type FieldDefinition struct {
+
+	/**
+		Name of the inject field
+	 */
+	Name      string
+
+	/**
+		Type of the inject field
+	 */
+	Type      reflect.Type
+
+	/**
+		Field could stay nil if implementation not found
+	 */
+	Optional  bool
+
+	/**
+		Name of the bean required by the field
+	 */
+	Qualifier string
+}
+
+/**
+	Read-only introspection, the type is resolved like Bean() does, but it is not cached in the context
+ */
+func (t *context) GetBeanDefinition(typ reflect.Type) (BeanDefinition, bool) {
+	b, ok := t.resolveBean(typ)
+	if !ok {
+		if t.parent != nil {
+			return t.parent.GetBeanDefinition(typ)
+		}
+		return BeanDefinition{}, false
+	}
+	def := BeanDefinition{
+		ClassType:   b.beanDef.classPtr,
+		IsSingleton: true,
+		Order:       b.beanDef.order,
+		Metadata:    copyMetadata(b.metadata),
+	}
+	for ifaceType, impl := range t.wiring() {
+		if impl == b && ifaceType.Kind() == reflect.Interface {
+			def.InterfacesImplemented = append(def.InterfacesImplemented, ifaceType)
+		}
+	}
+	sortTypes(def.InterfacesImplemented)
+	def.Fields = b.beanDef.fieldDefinitions()
+	return def, true
+}
+
+/**
+	Description of the object injected on runtime, such objects are not singletons
+ */
+func runtimeDefinition(bd *beanDef) BeanDefinition {
+	return BeanDefinition{
+		ClassType: bd.classPtr,
+		Order:     bd.order,
+		Fields:    bd.fieldDefinitions(),
+	}
+}
+
+func (t *beanDef) fieldDefinitions() []FieldDefinition {
+	var list []FieldDefinition
+	for _, f := range t.fields {
+		list = append(list, FieldDefinition{
+			Name:      f.fieldName,
+			Type:      f.fieldType,
+			Optional:  f.optional,
+			Qualifier: f.qualifier,
+		})
+	}
+	return list
+}
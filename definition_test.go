@@ -0,0 +1,76 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"log"
+	"os"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestGetBeanDefinition(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+
+	ctx, err := context.Create(
+		logger,
+		&storageImpl{},
+		&configServiceImpl{},
+		&userServiceImpl{},
+		context.Interface((*UserService)(nil)),
+	)
+	require.Nil(t, err)
+
+	def, ok := ctx.GetBeanDefinition(UserServiceClass)
+	require.True(t, ok)
+	require.Equal(t, reflect.TypeOf((*userServiceImpl)(nil)), def.ClassType)
+	require.Equal(t, []reflect.Type{UserServiceClass}, def.InterfacesImplemented)
+	require.True(t, def.IsSingleton)
+	require.False(t, def.IsLazy)
+	require.Equal(t, 0, def.Order)
+
+	require.Equal(t, 2, len(def.Fields))
+	require.Equal(t, "Storage", def.Fields[0].Name)
+	require.Equal(t, StorageClass, def.Fields[0].Type)
+	require.Equal(t, "ConfigService", def.Fields[1].Name)
+	require.Equal(t, ConfigServiceClass, def.Fields[1].Type)
+	require.False(t, def.Fields[0].Optional)
+	require.Equal(t, "", def.Fields[0].Qualifier)
+
+	_, ok = ctx.GetBeanDefinition(reflect.TypeOf((*memoryStorage)(nil)))
+	require.False(t, ok)
+
+	// introspection does not cache types in the context
+	bindings := ctx.Stats().Bindings
+	def, ok = ctx.GetBeanDefinition(reflect.TypeOf((*storageImpl)(nil)))
+	require.True(t, ok)
+	require.Equal(t, []reflect.Type{StorageClass}, def.InterfacesImplemented)
+	_, ok = ctx.GetBeanDefinition(reflect.TypeOf(logger))
+	require.True(t, ok)
+	require.Equal(t, bindings, ctx.Stats().Bindings)
+
+}
@@ -0,0 +1,280 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"reflect"
+	"sort"
+)
+
+/**
+@author Alex Shvid
+*/
+
+const (
+	DiffAdded    = "added"
+	DiffRemoved  = "removed"
+	DiffReplaced = "replaced"
+)
+
+/**
+	Difference in wiring of two contexts
+ */
+
+type DiffEntry struct {
+
+	/**
+		Class of the bean for added and removed beans, requested type for replaced implementations
+	 */
+	Type   reflect.Type
+
+	/**
+		One of "added", "removed" or "replaced"
+	 */
+	Change string
+}
+
+/**
+	Compare wiring of two contexts.
+	Beans in core of b but not a are added, beans in core of a but not b are removed.
+	Types bound to different implementations in a and b are replaced, the implementations themselves are not reported.
+	Bindings are computed from core and do not depend on types cached by earlier lookups.
+
+	Example:
+		for _, e := range context.ContextDiff(before, after) {
+			fmt.Printf("%s %v\n", e.Change, e.Type)
+		}
+ */
+func ContextDiff(a, b Context) []DiffEntry {
+	var diff []DiffEntry
+
+	replacedImpl := make(map[reflect.Type]bool)
+	bindings := bindingsOf(a)
+	for ifaceType, implType := range bindingsOf(b) {
+		if impl, ok := bindings[ifaceType]; ok && impl != implType {
+			diff = append(diff, DiffEntry{Type: ifaceType, Change: DiffReplaced})
+			replacedImpl[impl] = true
+			replacedImpl[implType] = true
+		}
+	}
+
+	coreA := typeSet(a.Core())
+	coreB := typeSet(b.Core())
+	for typ := range coreB {
+		if !coreA[typ] && !replacedImpl[typ] {
+			diff = append(diff, DiffEntry{Type: typ, Change: DiffAdded})
+		}
+	}
+	for typ := range coreA {
+		if !coreB[typ] && !replacedImpl[typ] {
+			diff = append(diff, DiffEntry{Type: typ, Change: DiffRemoved})
+		}
+	}
+
+	sort.Slice(diff, func(i, j int) bool {
+		return diff[i].Type.String() < diff[j].Type.String()
+	})
+	return diff
+}
+
+/**
+	Implementations of the types bound in the context
+ */
+func bindingsOf(ctx Context) map[reflect.Type]reflect.Type {
+	bindings := make(map[reflect.Type]reflect.Type)
+	for _, binding := range ctx.Bindings() {
+		bindings[binding.InterfaceType] = binding.ImplType
+	}
+	return bindings
+}
+
+/**
+	Instances of beans in core of the context by class, without lookups that cache types
+ */
+func coreObjects(ctx Context) map[reflect.Type]interface{} {
+	objects := make(map[reflect.Type]interface{})
+	if c, ok := ctx.(*context); ok {
+		for classPtr, b := range c.coreBeans() {
+			objects[classPtr] = b.obj
+		}
+		return objects
+	}
+	if c, ok := ctx.(*chainedContext); ok {
+		for i := len(c.contexts) - 1; i >= 0; i-- {
+			for classPtr, obj := range coreObjects(c.contexts[i]) {
+				objects[classPtr] = obj
+			}
+		}
+		return objects
+	}
+	for _, classPtr := range ctx.Core() {
+		if obj, ok := ctx.Bean(classPtr); ok {
+			objects[classPtr] = obj
+		}
+	}
+	return objects
+}
+
+func typeSet(list []reflect.Type) map[reflect.Type]bool {
+	set := make(map[reflect.Type]bool, len(list))
+	for _, typ := range list {
+		set[typ] = true
+	}
+	return set
+}
+
+/**
+	Detailed difference in wiring of two contexts returned by Diff()
+ */
+
+type WiringDiff struct {
+
+	/**
+		Classes of beans in core of the other context only
+	 */
+	Added         []reflect.Type
+
+	/**
+		Classes of beans in core of this context only
+	 */
+	Removed       []reflect.Type
+
+	/**
+		Types bound to different implementations
+	 */
+	Replaced      []reflect.Type
+
+	/**
+		Inject fields of beans present in both contexts that are wired to beans of different classes
+	 */
+	FieldsChanged []FieldChange
+}
+
+/**
+	Inject field wired to the bean of another class
+ */
+
+type FieldChange struct {
+
+	/**
+		Class of the bean that owns the field
+	 */
+	ClassType reflect.Type
+
+	/**
+		Name of the inject field
+	 */
+	Field     string
+
+	/**
+		Class of the injected bean in this context, nil if the field is not injected
+	 */
+	From      reflect.Type
+
+	/**
+		Class of the injected bean in the other context, nil if the field is not injected
+	 */
+	To        reflect.Type
+}
+
+func (t *context) Diff(other Context) WiringDiff {
+	return wiringDiff(t, other)
+}
+
+func wiringDiff(this, other Context) WiringDiff {
+	var diff WiringDiff
+	for _, e := range ContextDiff(this, other) {
+		switch e.Change {
+		case DiffAdded:
+			diff.Added = append(diff.Added, e.Type)
+		case DiffRemoved:
+			diff.Removed = append(diff.Removed, e.Type)
+		case DiffReplaced:
+			diff.Replaced = append(diff.Replaced, e.Type)
+		}
+	}
+	core := coreObjects(this)
+	coreOther := coreObjects(other)
+	classes := make([]reflect.Type, 0, len(core))
+	for classPtr := range core {
+		classes = append(classes, classPtr)
+	}
+	sortTypes(classes)
+	for _, typ := range classes {
+		b, ok := coreOther[typ]
+		if !ok {
+			continue
+		}
+		a := core[typ]
+		for _, name := range injectFieldNames(this, typ) {
+			from, to := injectedClass(a, name), injectedClass(b, name)
+			if from != to {
+				diff.FieldsChanged = append(diff.FieldsChanged, FieldChange{typ, name, from, to})
+			}
+		}
+	}
+	return diff
+}
+
+/**
+	Names of inject fields of the bean in core
+ */
+func injectFieldNames(ctx Context, classPtr reflect.Type) []string {
+	var names []string
+	if c, ok := ctx.(*context); ok {
+		if b, ok := c.coreBeans()[classPtr]; ok {
+			for _, f := range b.beanDef.fields {
+				names = append(names, f.fieldName)
+			}
+		}
+		return names
+	}
+	if c, ok := ctx.(*chainedContext); ok {
+		for _, e := range c.contexts {
+			if _, ok := coreObjects(e)[classPtr]; ok {
+				return injectFieldNames(e, classPtr)
+			}
+		}
+		return names
+	}
+	if def, ok := ctx.GetBeanDefinition(classPtr); ok {
+		for _, f := range def.Fields {
+			names = append(names, f.Name)
+		}
+	}
+	return names
+}
+
+/**
+	Class of the value in the field of the bean, nil if the field is empty or not found
+ */
+func injectedClass(obj interface{}, fieldName string) reflect.Type {
+	value := reflect.ValueOf(obj)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	field := value.Elem().FieldByName(fieldName)
+	if !field.IsValid() || field.IsZero() {
+		return nil
+	}
+	if field.Kind() == reflect.Interface {
+		return field.Elem().Type()
+	}
+	return field.Type()
+}
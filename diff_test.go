@@ -0,0 +1,110 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"log"
+	"os"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestContextDiff(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+
+	before, err := context.Create(
+		logger,
+		&storageImpl{},
+		&configServiceImpl{},
+	)
+	require.Nil(t, err)
+
+	after, err := context.Create(
+		&configStorage{},
+		&configServiceImpl{},
+		&userServiceImpl{},
+		logger,
+	)
+	require.Nil(t, err)
+
+	require.Equal(t, 0, len(context.ContextDiff(before, before)))
+
+	require.Equal(t, []context.DiffEntry{
+		{Type: reflect.TypeOf((*userServiceImpl)(nil)), Change: context.DiffAdded},
+		{Type: StorageClass, Change: context.DiffReplaced},
+	}, context.ContextDiff(before, after))
+
+}
+
+func TestDiff(t *testing.T) {
+
+	context.Verbose = false
+
+	before, err := context.Create(&configStorage{}, &configServiceImpl{})
+	require.Nil(t, err)
+
+	after, err := context.Create(&memoryStorage{}, &configServiceImpl{})
+	require.Nil(t, err)
+
+	diff := before.Diff(after)
+	require.Equal(t, []reflect.Type{StorageClass}, diff.Replaced)
+	require.Empty(t, diff.Added)
+	require.Empty(t, diff.Removed)
+	require.Equal(t, []context.FieldChange{
+		{reflect.TypeOf(&configServiceImpl{}), "Storage", reflect.TypeOf(&configStorage{}), reflect.TypeOf(&memoryStorage{})},
+	}, diff.FieldsChanged)
+
+	require.Empty(t, before.Diff(before).FieldsChanged)
+
+}
+
+func TestContextDiffLookups(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+
+	before, err := context.Create(logger, &storageImpl{})
+	require.Nil(t, err)
+
+	after, err := context.Create(logger, &memoryStorage{})
+	require.Nil(t, err)
+
+	expected := []context.DiffEntry{
+		{Type: reflect.TypeOf((*memoryStorage)(nil)), Change: context.DiffAdded},
+		{Type: reflect.TypeOf((*storageImpl)(nil)), Change: context.DiffRemoved},
+	}
+	require.Equal(t, expected, context.ContextDiff(before, after))
+	diff := before.Diff(after)
+
+	// lookups cache the interface in both contexts
+	require.NotNil(t, before.MustBean(StorageClass))
+	require.NotNil(t, after.MustBean(StorageClass))
+
+	require.Equal(t, expected, context.ContextDiff(before, after))
+	require.Equal(t, diff, before.Diff(after))
+
+}
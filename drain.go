@@ -0,0 +1,143 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"github.com/pkg/errors"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Track disposable objects created by WireNew() and AutoWire() until Release() is called.
+	Without this option such objects are not tracked and Drain() waits only for child contexts created by Fork()
+	and objects of non-singleton factories, see FactoryBean.
+
+	Example:
+		ctx, err := context.CreateWithOptions(beans, context.WithPrototypeTracking())
+		handler, err := context.WireNew(ctx, newRequestHandler)
+		defer ctx.Release(handler)
+ */
+func WithPrototypeTracking() Option {
+	return func(conf *contextConfig) {
+		conf.trackPrototypes = true
+	}
+}
+
+/**
+	Track the object created on runtime until it is released, see Drain()
+ */
+func (t *context) track(obj interface{}) {
+	t.inflightMu.Lock()
+	defer t.inflightMu.Unlock()
+	if t.inflightObjs == nil {
+		t.inflightObjs = make(map[interface{}]bool)
+	}
+	if !t.inflightObjs[obj] {
+		if len(t.inflightObjs) == 0 {
+			t.inflightIdle = make(chan struct{})
+		}
+		t.inflightObjs[obj] = true
+	}
+}
+
+func (t *context) tracks(obj interface{}) bool {
+	t.inflightMu.Lock()
+	defer t.inflightMu.Unlock()
+	return t.inflightObjs[obj]
+}
+
+/**
+	Stop tracking the object, returns false if the object is not tracked
+ */
+func (t *context) release(obj interface{}) bool {
+	t.inflightMu.Lock()
+	defer t.inflightMu.Unlock()
+	if !t.inflightObjs[obj] {
+		return false
+	}
+	delete(t.inflightObjs, obj)
+	if len(t.inflightObjs) == 0 {
+		close(t.inflightIdle)
+		t.inflightIdle = nil
+	}
+	return true
+}
+
+/**
+	Channel closed when all tracked objects are released, nil if nothing is tracked.
+	Objects tracked after the call are waited by the next call.
+ */
+func (t *context) idle() <-chan struct{} {
+	t.inflightMu.Lock()
+	defer t.inflightMu.Unlock()
+	return t.inflightIdle
+}
+
+func (t *context) Release(obj interface{}) error {
+	if obj == nil {
+		return errors.New("null obj is are not allowed")
+	}
+	if !t.tracks(obj) {
+		return errors.Errorf("object '%T' is not tracked by the context", obj)
+	}
+	var err error
+	if d, ok := obj.(DisposableBean); ok {
+		err = t.destroyBean(d)
+	}
+	t.release(obj)
+	return err
+}
+
+func (t *context) Drain() error {
+	if idle := t.idle(); idle != nil {
+		<-idle
+	}
+	return nil
+}
+
+func (t *context) DrainWithTimeout(d time.Duration) error {
+	idle := t.idle()
+	if idle == nil {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-idle:
+		return nil
+	case <-timer.C:
+		return errors.Errorf("drain timed out after %v", d)
+	}
+}
+
+/**
+	Track disposable objects created on runtime by WireNew() and AutoWire() if enabled by WithPrototypeTracking()
+ */
+func trackPrototype(ctx Context, obj interface{}) {
+	if _, ok := obj.(DisposableBean); !ok {
+		return
+	}
+	if t, ok := ctx.(*context); ok && t.conf.trackPrototypes {
+		t.track(obj)
+	}
+}
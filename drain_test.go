@@ -0,0 +1,166 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+var destroyedPrototypes int32
+
+type prototypeBean struct {
+	Storage Storage `inject`
+}
+
+func (t *prototypeBean) Destroy() error {
+	atomic.AddInt32(&destroyedPrototypes, 1)
+	return nil
+}
+
+func TestDrain(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.CreateWithOptions([]interface{}{&configStorage{}}, context.WithPrototypeTracking())
+	require.Nil(t, err)
+
+	atomic.StoreInt32(&destroyedPrototypes, 0)
+	created := make(chan *prototypeBean, 10)
+	for i := 0; i < 10; i++ {
+		go func() {
+			p, err := context.AutoWire[prototypeBean](ctx)
+			require.Nil(t, err)
+			created <- p
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		p := <-created
+		go func() {
+			time.Sleep(5 * time.Millisecond)
+			ctx.Release(p)
+		}()
+	}
+
+	require.Nil(t, ctx.Drain())
+	require.Equal(t, int32(10), atomic.LoadInt32(&destroyedPrototypes))
+
+}
+
+func TestDrainWithTimeout(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create(&configStorage{})
+	require.Nil(t, err)
+
+	child, err := ctx.Fork(&prototypeBean{})
+	require.Nil(t, err)
+
+	err = ctx.DrainWithTimeout(10 * time.Millisecond)
+	require.NotNil(t, err)
+
+	require.Nil(t, child.Close())
+	require.Nil(t, child.Close())
+	require.Nil(t, ctx.DrainWithTimeout(10 * time.Millisecond))
+
+	// prototypes without Destroy() are not tracked
+	_, err = context.AutoWire[struct{ Storage Storage `inject` }](ctx)
+	require.Nil(t, err)
+	require.Nil(t, ctx.Drain())
+
+}
+
+func TestDrainWithoutTracking(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create(&configStorage{})
+	require.Nil(t, err)
+
+	p, err := context.AutoWire[prototypeBean](ctx)
+	require.Nil(t, err)
+
+	require.Nil(t, ctx.DrainWithTimeout(10 * time.Millisecond))
+	require.NotNil(t, ctx.Release(p))
+
+}
+
+type prototypeBeanFactory struct {
+}
+
+func (t *prototypeBeanFactory) Object() interface{} {
+	return &prototypeBean{}
+}
+
+func (t *prototypeBeanFactory) ObjectType() reflect.Type {
+	return reflect.TypeOf((*prototypeBean)(nil))
+}
+
+func (t *prototypeBeanFactory) Singleton() bool {
+	return false
+}
+
+type prototypeConsumer struct {
+	NewPrototype func() *prototypeBean `inject`
+}
+
+func TestDrainFactoryPrototypes(t *testing.T) {
+
+	context.Verbose = false
+	storage := &configStorage{}
+	consumer := &prototypeConsumer{}
+
+	ctx, err := context.Create(storage, &prototypeBeanFactory{}, consumer)
+	require.Nil(t, err)
+
+	atomic.StoreInt32(&destroyedPrototypes, 0)
+	created := make(chan *prototypeBean, 10)
+	for i := 0; i < 10; i++ {
+		go func() {
+			created <- consumer.NewPrototype()
+		}()
+	}
+	seen := make(map[*prototypeBean]bool)
+	for i := 0; i < 10; i++ {
+		p := <-created
+		require.Equal(t, Storage(storage), p.Storage)
+		seen[p] = true
+		go func() {
+			time.Sleep(5 * time.Millisecond)
+			ctx.Release(p)
+		}()
+	}
+	require.Equal(t, 10, len(seen))
+
+	require.Nil(t, ctx.Drain())
+	require.Equal(t, int32(10), atomic.LoadInt32(&destroyedPrototypes))
+
+	// singletons are not released
+	require.NotNil(t, ctx.Release(storage))
+
+}
@@ -0,0 +1,125 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"github.com/pkg/errors"
+	"os"
+	"reflect"
+	"strings"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Field with 'env' tag, for example `env:"DATABASE_URL"` or `env:"PORT,default=8080"`
+ */
+type envInjection struct {
+	/**
+		Class of that struct
+	*/
+	class        reflect.Type
+	/**
+		Field number of that struct
+	*/
+	fieldNum     int
+	/**
+		Field name where environment variable is going to be set
+	*/
+	fieldName    string
+	/**
+		Type of the field
+	*/
+	fieldType    reflect.Type
+	/**
+		Name of the environment variable
+	*/
+	name         string
+	/**
+		Default value if environment variable is empty
+	*/
+	defaultValue string
+	hasDefault   bool
+	/**
+		Error of parsing the tag, returned on injection because the tag is ignored without WithEnvironmentVariables
+	*/
+	invalid      error
+}
+
+func parseEnv(class reflect.Type, fieldNum int, field reflect.StructField, expr string) (*envInjection, error) {
+	parts := strings.Split(expr, ",")
+	def := &envInjection{
+		class:     class,
+		fieldNum:  fieldNum,
+		fieldName: field.Name,
+		fieldType: field.Type,
+		name:      strings.TrimSpace(parts[0]),
+	}
+	if def.name == "" {
+		return nil, errors.Errorf("empty environment variable name on field '%s' in class '%v'", field.Name, class)
+	}
+	for _, opt := range parts[1:] {
+		opt = strings.TrimSpace(opt)
+		if strings.HasPrefix(opt, "default=") {
+			def.defaultValue, def.hasDefault = strings.TrimPrefix(opt, "default="), true
+		} else {
+			return nil, errors.Errorf("unknown option '%s' in env tag on field '%s' in class '%v'", opt, field.Name, class)
+		}
+	}
+	if def.hasDefault {
+		if _, err := convertProperty(def.defaultValue, def.fieldType); err != nil {
+			return nil, errors.Errorf("invalid default value on field '%s' in class '%v', %v", field.Name, class, err)
+		}
+	}
+	return def, nil
+}
+
+func injectEnvs(value reflect.Value, bd *beanDef) error {
+	for _, e := range bd.envs {
+		if err := e.inject(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *envInjection) inject(value reflect.Value) error {
+	if t.invalid != nil {
+		return t.invalid
+	}
+	str := os.Getenv(t.name)
+	if str == "" {
+		if !t.hasDefault {
+			return errors.Errorf("environment variable '%s' is empty for field '%s' in class '%v' without default value", t.name, t.fieldName, t.class)
+		}
+		str = t.defaultValue
+	}
+	field := value.Field(t.fieldNum)
+	if !field.CanSet() {
+		return errors.Errorf("field '%s' in class '%v' is not public", t.fieldName, t.class)
+	}
+	converted, err := convertProperty(str, t.fieldType)
+	if err != nil {
+		return errors.Errorf("environment variable '%s' for field '%s' in class '%v', %v", t.name, t.fieldName, t.class, err)
+	}
+	field.Set(converted)
+	return nil
+}
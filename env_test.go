@@ -0,0 +1,93 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type databaseConfig struct {
+	URL  string `env:"CONTEXT_TEST_DATABASE_URL"`
+	Port int    `env:"CONTEXT_TEST_PORT,default=8080"`
+}
+
+func TestEnvironmentVariables(t *testing.T) {
+
+	context.Verbose = false
+	t.Setenv("CONTEXT_TEST_DATABASE_URL", "postgres://localhost/db")
+
+	config := &databaseConfig{}
+	_, err := context.CreateWithOptions([]interface{}{config}, context.WithEnvironmentVariables())
+	require.Nil(t, err)
+	require.Equal(t, "postgres://localhost/db", config.URL)
+	require.Equal(t, 8080, config.Port)
+
+	t.Setenv("CONTEXT_TEST_PORT", "9090")
+	config = &databaseConfig{}
+	_, err = context.CreateWithOptions([]interface{}{config}, context.WithEnvironmentVariables())
+	require.Nil(t, err)
+	require.Equal(t, 9090, config.Port)
+
+}
+
+func TestEnvironmentVariableMissing(t *testing.T) {
+
+	context.Verbose = false
+	t.Setenv("CONTEXT_TEST_DATABASE_URL", "")
+
+	_, err := context.CreateWithOptions([]interface{}{&databaseConfig{}}, context.WithEnvironmentVariables())
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "CONTEXT_TEST_DATABASE_URL")
+
+}
+
+func TestEnvironmentVariablesDisabled(t *testing.T) {
+
+	context.Verbose = false
+	t.Setenv("CONTEXT_TEST_DATABASE_URL", "postgres://localhost/db")
+
+	config := &databaseConfig{}
+	_, err := context.Create(config)
+	require.Nil(t, err)
+	require.Equal(t, "", config.URL)
+
+}
+
+func TestEnvironmentVariableInvalidTag(t *testing.T) {
+
+	context.Verbose = false
+
+	config := &struct {
+		Port int `env:"CONTEXT_TEST_PORT,required"`
+	}{}
+
+	_, err := context.Create(config)
+	require.Nil(t, err)
+
+	_, err = context.CreateWithOptions([]interface{}{config}, context.WithEnvironmentVariables())
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "unknown option 'required'")
+
+}
@@ -0,0 +1,84 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Bean in core that receives errors of PostConstruct() calls on creation of context and Destroy() calls on close.
+	Phase is LifecyclePostConstruct or LifecycleDestroy.
+	Returns true if the error is handled and must not be returned by Create() or Close().
+
+	Only handlers that passed their own initialization and are not destroyed yet receive errors,
+	therefore a handler does not receive errors of its dependencies on creation and of its dependents on close.
+	With WithCloseParallelism() the handler is called concurrently from multiple goroutines and must be safe for concurrent use.
+ */
+
+type ErrorHandler interface {
+
+	HandleContextError(phase string, err error) bool
+
+}
+
+/**
+	Pass error to active handlers in order of initialization, stops on the first handler that returns true
+ */
+func (t *context) handleError(phase string, err error) bool {
+	for _, step := range initOrder(t.coreBeans(), t.conf.startupOrder) {
+		if h, ok := step.bean.obj.(ErrorHandler); ok && t.isActiveHandler(h) && h.HandleContextError(phase, err) {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+	Handler starts to receive errors after successful initialization
+ */
+func (t *context) activateHandler(obj interface{}) {
+	if _, ok := obj.(ErrorHandler); !ok {
+		return
+	}
+	t.activeHandlersMu.Lock()
+	defer t.activeHandlersMu.Unlock()
+	if t.activeHandlers == nil {
+		t.activeHandlers = make(map[interface{}]bool)
+	}
+	t.activeHandlers[obj] = true
+}
+
+/**
+	Handler stops to receive errors before destruction
+ */
+func (t *context) deactivateHandler(obj interface{}) {
+	if _, ok := obj.(ErrorHandler); !ok {
+		return
+	}
+	t.activeHandlersMu.Lock()
+	defer t.activeHandlersMu.Unlock()
+	delete(t.activeHandlers, obj)
+}
+
+func (t *context) isActiveHandler(obj interface{}) bool {
+	t.activeHandlersMu.RLock()
+	defer t.activeHandlersMu.RUnlock()
+	return t.activeHandlers[obj]
+}
@@ -0,0 +1,123 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"errors"
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type contextError struct {
+	phase string
+	err   error
+}
+
+type recordingErrorHandler struct {
+	sync.Mutex
+	handle bool
+	errors []contextError
+}
+
+func (t *recordingErrorHandler) HandleContextError(phase string, err error) bool {
+	t.Lock()
+	defer t.Unlock()
+	t.errors = append(t.errors, contextError{phase, err})
+	return t.handle
+}
+
+type failingLifecycleBean struct {
+	initErr    error
+	destroyErr error
+}
+
+func (t *failingLifecycleBean) PostConstruct() error {
+	return t.initErr
+}
+
+func (t *failingLifecycleBean) Destroy() error {
+	return t.destroyErr
+}
+
+func TestErrorHandler(t *testing.T) {
+
+	context.Verbose = false
+
+	initErr, destroyErr := errors.New("init failed"), errors.New("destroy failed")
+
+	handler := &recordingErrorHandler{handle: true}
+	ctx, err := context.Create(handler, &failingLifecycleBean{initErr: initErr, destroyErr: destroyErr})
+	require.Nil(t, err)
+	require.Nil(t, ctx.Close())
+	require.Equal(t, []contextError{
+		{context.LifecyclePostConstruct, initErr},
+		{context.LifecycleDestroy, destroyErr},
+	}, handler.errors)
+
+	handler = &recordingErrorHandler{}
+	_, err = context.Create(handler, &failingLifecycleBean{initErr: initErr})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "init failed")
+	require.Equal(t, []contextError{{context.LifecyclePostConstruct, initErr}}, handler.errors)
+
+}
+
+type lifecycleErrorHandler struct {
+	recordingErrorHandler
+	Bean *failingLifecycleBean `inject`
+}
+
+func (t *lifecycleErrorHandler) PostConstruct() error {
+	return nil
+}
+
+func (t *lifecycleErrorHandler) Destroy() error {
+	return nil
+}
+
+func TestErrorHandlerLifecycle(t *testing.T) {
+
+	context.Verbose = false
+
+	initErr, destroyErr := errors.New("init failed"), errors.New("destroy failed")
+
+	// dependency is initialized before the handler
+	handler := &lifecycleErrorHandler{recordingErrorHandler: recordingErrorHandler{handle: true}}
+	_, err := context.Create(handler, &failingLifecycleBean{initErr: initErr})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "init failed")
+	require.Empty(t, handler.errors)
+
+	// dependency is destroyed after the handler
+	handler = &lifecycleErrorHandler{recordingErrorHandler: recordingErrorHandler{handle: true}}
+	ctx, err := context.CreateWithOptions(
+		[]interface{}{handler, &failingLifecycleBean{destroyErr: destroyErr}},
+		context.WithCloseParallelism(2),
+	)
+	require.Nil(t, err)
+	require.Equal(t, destroyErr, ctx.Close())
+	require.Empty(t, handler.errors)
+
+}
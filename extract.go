@@ -0,0 +1,50 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"strings"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Beans in core whose struct type is declared in the package or its sub-packages, sorted by type name.
+	Package is a prefix of the import path, for example 'github.com/consensusdb/app/domain'.
+ */
+func (t *context) ExtractBeans(pkg string) []interface{} {
+	var list []*bean
+	for classPtr, b := range t.coreBeans() {
+		if matchPackage(classPtr.Elem().PkgPath(), pkg) {
+			list = append(list, b)
+		}
+	}
+	sortBeans(list)
+	var res []interface{}
+	for _, b := range list {
+		res = append(res, b.exposed)
+	}
+	return res
+}
+
+func matchPackage(path, pkg string) bool {
+	return path == pkg || strings.HasPrefix(path, strings.TrimSuffix(pkg, "/") + "/")
+}
@@ -0,0 +1,57 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"bytes"
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"log"
+	"os"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestExtractBeans(t *testing.T) {
+
+	context.Verbose = false
+
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+	buffer := &bytes.Buffer{}
+	storage := &configStorage{}
+
+	ctx, err := context.Create(
+		logger,
+		buffer,
+		storage,
+	)
+	require.Nil(t, err)
+
+	require.Equal(t, []interface{}{logger}, ctx.ExtractBeans("log"))
+	require.Equal(t, []interface{}{buffer}, ctx.ExtractBeans("bytes"))
+	require.Equal(t, []interface{}{storage}, ctx.ExtractBeans("github.com/consensusdb/context_test"))
+	require.Equal(t, []interface{}{storage}, ctx.ExtractBeans("github.com/consensusdb/"))
+
+	require.Empty(t, ctx.ExtractBeans("github.com/consensusdb/context"))
+	require.Empty(t, ctx.ExtractBeans("net/http"))
+
+}
@@ -0,0 +1,254 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	stdcontext "context"
+	"github.com/pkg/errors"
+	"reflect"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func isFactoryBean(obj interface{}) bool {
+	switch obj.(type) {
+	case FactoryBean, FactoryBeanV2:
+		return true
+	default:
+		return false
+	}
+}
+
+/**
+	Inject fields of the bean on creation of context without runtime hooks, used for factories and their products.
+	Dependencies are searched in core, by name and in the parent context.
+ */
+func (t *context) wireBean(b *bean) error {
+	value := b.valuePtr.Elem()
+	if err := injectProperties(value, b.beanDef, t.conf.propertySources); err != nil {
+		return err
+	}
+	for _, injectDef := range b.beanDef.fields {
+		inject := &injection{value: value, injectionDef: injectDef, owner: b}
+		if injectDef.fieldType.Kind() == reflect.Func {
+			if err := injectDef.set(&inject.value, t.provider(injectDef.fieldType), t.conf.unexportedFields); err != nil {
+				return err
+			}
+			continue
+		}
+		impl, ok := t.dependency(injectDef)
+		if !ok {
+			if injectDef.optional {
+				continue
+			}
+			return errors.Errorf("can not find dependency '%v' required by %v", injectDef.fieldType, inject)
+		}
+		if err := t.injectTraced(inject, impl); err != nil {
+			return err
+		}
+	}
+	if t.conf.environment {
+		return injectEnvs(value, b.beanDef)
+	}
+	return nil
+}
+
+func (t *context) dependency(injectDef *injectionDef) (*bean, bool) {
+	if injectDef.qualifier != "" {
+		return t.registry.findBeanByName(injectDef.qualifier)
+	}
+	if injectDef.fieldType.Kind() == reflect.Ptr {
+		if b, ok := t.core[injectDef.fieldType]; ok {
+			return b, true
+		}
+		return t.parentBean(injectDef.fieldType)
+	}
+	b, err := searchByInterface(injectDef.fieldType, t.core)
+	if err != nil {
+		if len(findCandidates(injectDef.fieldType, t.core)) == 0 {
+			return t.parentBean(injectDef.fieldType)
+		}
+		return nil, false
+	}
+	return b, true
+}
+
+/**
+	Check if the factory bean creates a new object on each request, returns the type of objects
+ */
+func prototypeFactory(obj interface{}) (reflect.Type, bool) {
+	switch fb := obj.(type) {
+	case FactoryBean:
+		return fb.ObjectType(), !fb.Singleton()
+	case FactoryBeanV2:
+		return fb.ObjectType(), !fb.Singleton()
+	default:
+		return nil, false
+	}
+}
+
+/**
+	Call Object() of the wired singleton factory bean
+ */
+func (t *context) createProduct(f scannedBean) (*bean, error) {
+	var (
+		product    interface{}
+		objectType reflect.Type
+		err        error
+	)
+	switch fb := f.bean.obj.(type) {
+	case FactoryBean:
+		product, objectType = fb.Object(), fb.ObjectType()
+	case FactoryBeanV2:
+		product, err = fb.Object()
+		objectType = fb.ObjectType()
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "factory '%v' on position %d failed to create object", f.bean.beanDef.classPtr, f.position)
+	}
+	if product == nil {
+		return nil, errors.Errorf("factory '%v' on position %d created null object", f.bean.beanDef.classPtr, f.position)
+	}
+	classPtr := reflect.TypeOf(product)
+	if objectType != nil && !classPtr.AssignableTo(objectType) {
+		return nil, errors.Errorf("factory '%v' on position %d created object of type '%v' that is not '%v'", f.bean.beanDef.classPtr, f.position, classPtr, objectType)
+	}
+	if classPtr.Kind() != reflect.Ptr || classPtr.Elem().Kind() != reflect.Struct {
+		return nil, errors.Errorf("factory '%v' on position %d created object of type '%v' that is not a pointer to struct", f.bean.beanDef.classPtr, f.position, classPtr)
+	}
+	b, err := investigate(product, classPtr)
+	if err != nil {
+		return nil, err
+	}
+	b.position = f.position
+	return b, nil
+}
+
+/**
+	Create the object by the non-singleton factory, inject fields in to it and call PostConstruct().
+	The object is tracked until Release() if it implements DisposableBean, see Drain().
+ */
+func (t *context) newPrototype(factory *bean, objectType reflect.Type) (interface{}, error) {
+	var (
+		product interface{}
+		err     error
+	)
+	switch fb := factory.obj.(type) {
+	case FactoryBean:
+		product = fb.Object()
+	case FactoryBeanV2:
+		product, err = fb.Object()
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "factory '%v' failed to create object", factory.beanDef.classPtr)
+	}
+	if product == nil {
+		return nil, errors.Errorf("factory '%v' created null object", factory.beanDef.classPtr)
+	}
+	if !reflect.TypeOf(product).AssignableTo(objectType) {
+		return nil, errors.Errorf("factory '%v' created object of type '%T' that is not '%v'", factory.beanDef.classPtr, product, objectType)
+	}
+	if _, err := t.Inject(product); err != nil {
+		return nil, err
+	}
+	if _, err := t.initBean(stdcontext.Background(), product); err != nil {
+		return nil, errors.Wrapf(err, "initializing %T", product)
+	}
+	if _, ok := product.(DisposableBean); ok {
+		t.track(product)
+	}
+	return product, nil
+}
+
+/**
+	Function that creates the bean from the beans of the context.
+	The context is partially created, all beans except products of other factories are wired but not initialized yet.
+ */
+type BeanFactory func(ctx Context) (interface{}, error)
+
+type functionFactory struct {
+	beanType reflect.Type
+	factory  BeanFactory
+}
+
+/**
+	Function factory and its position in the scan list
+ */
+type scannedFactory struct {
+	position int
+	factory  functionFactory
+}
+
+/**
+	Register the bean created by the function after wiring of all other beans.
+	Bean type is a pointer to the structure or interface, the bean is available by it and by its own class.
+	Inject fields of the created bean are wired on runtime, PostConstruct() and Destroy() are called as usual.
+
+	Example:
+		ctx, err := context.Create(
+			&config{},
+			context.Factory(DatabaseConnectionClass, func(ctx context.Context) (interface{}, error) {
+				cfg := ctx.MustBean(ConfigClass).(*config)
+				return sql.Open("postgres", cfg.URL)
+			}),
+		)
+ */
+func Factory(beanType reflect.Type, factory BeanFactory) interface{} {
+	return functionFactory{beanType: beanType, factory: factory}
+}
+
+func (t *context) runFactory(f scannedFactory) error {
+	beanType := f.factory.beanType
+	product, err := f.factory.factory(t)
+	if err != nil {
+		return errors.Wrapf(err, "factory of '%v' on position %d failed to create object", beanType, f.position)
+	}
+	if product == nil {
+		return errors.Errorf("factory of '%v' on position %d created null object", beanType, f.position)
+	}
+	classPtr := reflect.TypeOf(product)
+	if !classPtr.AssignableTo(beanType) {
+		return errors.Errorf("factory of '%v' on position %d created object of type '%v'", beanType, f.position, classPtr)
+	}
+	if classPtr.Kind() != reflect.Ptr || classPtr.Elem().Kind() != reflect.Struct {
+		return errors.Errorf("factory of '%v' on position %d created object of type '%v' that is not a pointer to struct", beanType, f.position, classPtr)
+	}
+	if _, ok := t.core[classPtr]; ok {
+		return errors.Errorf("repeated instance on position %d of type '%v' created by factory", f.position, classPtr)
+	}
+	b, err := investigate(product, classPtr)
+	if err != nil {
+		return err
+	}
+	if err := t.wireBean(b); err != nil {
+		return errors.Wrapf(err, "factory of '%v' on position %d", beanType, f.position)
+	}
+	b.position = f.position
+	t.core[classPtr] = b
+	t.registry.addBean(classPtr, b)
+	if beanType != classPtr {
+		t.registry.addBean(beanType, b)
+	}
+	t.notify(func(o ContextObserver) {
+		o.OnBeanRegistered(classPtr, product)
+	})
+	return nil
+}
@@ -0,0 +1,202 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"errors"
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type databaseConnection struct {
+	url string
+}
+
+var databaseConnectionClass = reflect.TypeOf((*databaseConnection)(nil))
+
+type connectionConfig struct {
+	ConnectionString string
+}
+
+type DatabaseConnectionFactory struct {
+	Config *connectionConfig `inject`
+}
+
+func (t *DatabaseConnectionFactory) Object() (interface{}, error) {
+	if t.Config.ConnectionString == "" {
+		return nil, errors.New("connection string is not configured")
+	}
+	return &databaseConnection{url: t.Config.ConnectionString}, nil
+}
+
+func (t *DatabaseConnectionFactory) ObjectType() reflect.Type {
+	return databaseConnectionClass
+}
+
+func (t *DatabaseConnectionFactory) Singleton() bool {
+	return true
+}
+
+type repository struct {
+	Conn *databaseConnection `inject`
+}
+
+func TestFactoryBeanV2(t *testing.T) {
+
+	context.Verbose = false
+	repo := &repository{}
+	factory := &DatabaseConnectionFactory{}
+
+	ctx, err := context.Create(&connectionConfig{ConnectionString: "postgres://localhost/db"}, factory, repo)
+	require.Nil(t, err)
+	require.Equal(t, 4, len(ctx.Core()))
+	require.Equal(t, "postgres://localhost/db", repo.Conn.url)
+	require.Equal(t, repo.Conn, ctx.MustBean(databaseConnectionClass))
+	require.Equal(t, factory, ctx.MustBean(reflect.TypeOf(factory)))
+
+	_, err = context.Create(&connectionConfig{}, &DatabaseConnectionFactory{}, &repository{})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "*context_test.DatabaseConnectionFactory")
+	require.Contains(t, err.Error(), "connection string is not configured")
+
+	_, err = context.Create(&DatabaseConnectionFactory{}, &repository{})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "*context_test.connectionConfig")
+
+}
+
+type storageFactory struct {
+}
+
+func (t *storageFactory) Object() interface{} {
+	return &configStorage{}
+}
+
+func (t *storageFactory) ObjectType() reflect.Type {
+	return StorageClass
+}
+
+func (t *storageFactory) Singleton() bool {
+	return true
+}
+
+type prototypeStorageFactory struct {
+	storageFactory
+}
+
+func (t *prototypeStorageFactory) Singleton() bool {
+	return false
+}
+
+func TestFactoryBean(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create(&storageFactory{}, context.Interface((*Storage)(nil)))
+	require.Nil(t, err)
+	_, ok := ctx.MustBean(StorageClass).(*configStorage)
+	require.True(t, ok)
+
+	// objects of non-singleton factory are created by providers only
+	ctx, err = context.Create(&prototypeStorageFactory{})
+	require.Nil(t, err)
+	_, ok = ctx.Bean(StorageClass)
+	require.False(t, ok)
+
+}
+
+type factoryConfig struct {
+	URL string
+}
+
+var factoryConfigClass = reflect.TypeOf((*factoryConfig)(nil))
+
+func TestFunctionFactory(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create(
+		&factoryConfig{URL: "postgres://localhost/db"},
+		context.Factory(databaseConnectionClass, func(ctx context.Context) (interface{}, error) {
+			cfg := ctx.MustBean(factoryConfigClass).(*factoryConfig)
+			return &databaseConnection{url: cfg.URL}, nil
+		}),
+	)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(ctx.Core()))
+
+	conn, ok := ctx.Bean(databaseConnectionClass)
+	require.True(t, ok)
+	require.Equal(t, "postgres://localhost/db", conn.(*databaseConnection).url)
+
+	_, err = context.Create(
+		context.Factory(databaseConnectionClass, func(ctx context.Context) (interface{}, error) {
+			return nil, errors.New("connection refused")
+		}),
+	)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "connection refused")
+
+	_, err = context.Create(
+		context.Factory(databaseConnectionClass, func(ctx context.Context) (interface{}, error) {
+			return &factoryConfig{}, nil
+		}),
+	)
+	require.NotNil(t, err)
+
+	counter := 0
+	_, err = context.Create(
+		context.Factory(reflect.TypeOf((*interface{})(nil)).Elem(), func(ctx context.Context) (interface{}, error) {
+			return &counter, nil
+		}),
+	)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "not a pointer to struct")
+
+}
+
+type factoryProduct struct {
+	Config *factoryConfig `inject`
+}
+
+func TestFunctionFactoryWiring(t *testing.T) {
+
+	context.Verbose = false
+	hooks := 0
+
+	config := &factoryConfig{}
+	ctx, err := context.CreateWithOptions([]interface{}{
+		config,
+		context.Factory(reflect.TypeOf((*factoryProduct)(nil)), func(ctx context.Context) (interface{}, error) {
+			return &factoryProduct{}, nil
+		}),
+	}, context.WithBeforeInject(func(obj interface{}, bd context.BeanDefinition) {
+		hooks++
+	}))
+	require.Nil(t, err)
+	require.Equal(t, 0, hooks)
+	require.Equal(t, config, ctx.MustBean(reflect.TypeOf((*factoryProduct)(nil))).(*factoryProduct).Config)
+
+}
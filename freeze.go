@@ -0,0 +1,41 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"github.com/pkg/errors"
+	"sync/atomic"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Returned by mutating operations after Freeze()
+ */
+var ErrContextFrozen = errors.New("context is frozen")
+
+func (t *context) Freeze() {
+	atomic.StoreInt32(&t.frozen, 1)
+}
+
+func (t *context) IsFrozen() bool {
+	return atomic.LoadInt32(&t.frozen) == 1
+}
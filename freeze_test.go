@@ -0,0 +1,57 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestFreeze(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create(&configStorage{}, &configServiceImpl{})
+	require.Nil(t, err)
+	require.False(t, ctx.IsFrozen())
+
+	snap := ctx.Snapshot()
+	ctx.Freeze()
+	ctx.Freeze()
+	require.True(t, ctx.IsFrozen())
+
+	_, err = ctx.Inject(&struct{ Storage Storage `inject` }{})
+	require.Equal(t, context.ErrContextFrozen, err)
+	require.Equal(t, context.ErrContextFrozen, ctx.Mock(StorageClass, &configStorage{}))
+	require.Equal(t, context.ErrContextFrozen, ctx.Restore(snap))
+	require.Equal(t, context.ErrContextFrozen, context.Decorate[Storage](ctx, func(s Storage) Storage {
+		return s
+	}))
+
+	storage, ok := ctx.Bean(StorageClass)
+	require.True(t, ok)
+	require.NotNil(t, storage)
+	require.Equal(t, 2, len(ctx.Core()))
+
+}
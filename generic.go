@@ -0,0 +1,175 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"fmt"
+	"sync"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Gets bean by type T, that is a pointer to the structure or interface.
+
+	Example:
+		userService, ok := context.BeanOf[app.UserService](ctx)
+ */
+
+func BeanOf[T any](ctx Context) (T, bool) {
+	var empty T
+	if b, ok := ctx.Bean(TokenOf[T]().Type()); ok {
+		if bean, ok := b.(T); ok {
+			return bean, true
+		}
+	}
+	return empty, false
+}
+
+/**
+	Panic if bean not found
+ */
+func MustBeanOf[T any](ctx Context) T {
+	if bean, ok := BeanOf[T](ctx); ok {
+		return bean
+	} else {
+		panic(fmt.Sprintf("bean not found %v", TokenOf[T]().Type()))
+	}
+}
+
+/**
+	Panic if err is not nil, otherwise returns val. Removes boilerplate of error handling in tests.
+
+	Example:
+		ctx := context.Checked(context.Create(&userService{}))
+ */
+func Checked[T any](val T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+/**
+	Gets all beans implementing interface T in the order of scan list.
+
+	Example:
+		handlers := context.BeansImplementing[http.Handler](ctx)
+ */
+func BeansImplementing[T any](ctx Context) []T {
+	var list []T
+	for _, b := range ctx.FindAll(TokenOf[T]().Type()) {
+		if bean, ok := b.(T); ok {
+			list = append(list, bean)
+		}
+	}
+	return list
+}
+
+/**
+	Gets the first bean implementing interface T in the order of scan list.
+	Unlike BeanOf, multiple implementations are not an error.
+
+	Example:
+		cache, ok := context.LookupFirstOf[app.Cache](ctx)
+ */
+func LookupFirstOf[T any](ctx Context) (T, bool) {
+	var empty T
+	for _, b := range ctx.FindAll(TokenOf[T]().Type()) {
+		if bean, ok := b.(T); ok {
+			return bean, true
+		}
+	}
+	return empty, false
+}
+
+/**
+	Gets bean of type *T if registered, otherwise creates it by factory and injects fields on runtime.
+	Created object is not registered in the context and has no lifecycle.
+	The factory is called once per context created by this package, including contexts of Chain(), Async() and With(),
+	for other implementations of Context, for example NoopContext, the factory is called on each call.
+	Panics if injection of the created object fails, the failure is not cached and the next call runs the factory again.
+
+	Example:
+		exporter := context.LookupOrCreate(ctx, func() *reportExporter {
+			return &reportExporter{format: "csv"}
+		})
+ */
+func LookupOrCreate[T any](ctx Context, factory func() *T) *T {
+	if bean, ok := BeanOf[*T](ctx); ok {
+		return bean
+	}
+	create := func() *T {
+		obj := factory()
+		if _, err := ctx.Inject(obj); err != nil {
+			panic(err)
+		}
+		return obj
+	}
+	objects, ok := lazyObjectsOf(ctx)
+	if !ok {
+		return create()
+	}
+	lo, _ := objects.LoadOrStore(TokenOf[*T]().Type(), &lazyObject{})
+	return lo.(*lazyObject).get(func() interface{} {
+		return create()
+	}).(*T)
+}
+
+/**
+	Objects created by LookupOrCreate() in the context, false for implementations of Context outside of this package
+ */
+func lazyObjectsOf(ctx Context) (*sync.Map, bool) {
+	switch c := ctx.(type) {
+	case *context:
+		return &c.lazy, true
+	case *valueContext:
+		return &c.lazy, true
+	case *chainedContext:
+		return &c.lazy, true
+	case *asyncContext:
+		return lazyObjectsOf(c.wait())
+	default:
+		return nil, false
+	}
+}
+
+/**
+	Object created on first successful access
+ */
+type lazyObject struct {
+	mu   sync.Mutex
+	done bool
+	obj  interface{}
+}
+
+/**
+	Create the object if it is not created yet, panic of create is passed to the caller and the next call tries again
+ */
+func (t *lazyObject) get(create func() interface{}) interface{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.done {
+		t.obj = create()
+		t.done = true
+	}
+	return t.obj
+}
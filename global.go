@@ -0,0 +1,77 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"sync/atomic"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Package-level context of the application, value is globalHolder
+ */
+var global atomic.Value
+
+type globalHolder struct {
+	ctx Context
+}
+
+/**
+	Set package-level context, usually in main function after Create()
+ */
+func SetGlobal(ctx Context) {
+	global.Store(globalHolder{ctx})
+}
+
+/**
+	Get package-level context if set
+ */
+func GetGlobal() (Context, bool) {
+	if h, ok := global.Load().(globalHolder); ok && h.ctx != nil {
+		return h.ctx, true
+	}
+	return nil, false
+}
+
+/**
+	Gets bean by type T from package-level context, panic if context is not set or bean not found
+
+	Example:
+		userService := context.BeanG[app.UserService]()
+ */
+func BeanG[T any]() T {
+	ctx, ok := GetGlobal()
+	if !ok {
+		panic("global context is not set")
+	}
+	return MustBeanOf[T](ctx)
+}
+
+/**
+	Close package-level context and clear it
+ */
+func DestroyGlobal() error {
+	if h, ok := global.Swap(globalHolder{}).(globalHolder); ok && h.ctx != nil {
+		return h.ctx.Close()
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"log"
+	"net/http"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestGlobal(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+
+	ctx, err := context.Create(logger, &storageImpl{})
+	require.Nil(t, err)
+
+	_, ok := context.GetGlobal()
+	require.False(t, ok)
+	require.Panics(t, func() {
+		context.BeanG[Storage]()
+	})
+
+	context.SetGlobal(ctx)
+	actual, ok := context.GetGlobal()
+	require.True(t, ok)
+	require.Equal(t, ctx, actual)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			context.SetGlobal(ctx)
+		}()
+		go func() {
+			defer wg.Done()
+			require.NotNil(t, context.BeanG[Storage]())
+			require.Equal(t, logger, context.BeanG[*log.Logger]())
+		}()
+	}
+	wg.Wait()
+
+	require.Nil(t, context.DestroyGlobal())
+	_, ok = context.GetGlobal()
+	require.False(t, ok)
+	require.Equal(t, context.PhaseClosed, ctx.Phase())
+
+}
+
+func TestBeanOf(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+
+	ctx, err := context.Create(logger, &storageImpl{})
+	require.Nil(t, err)
+
+	storage, ok := context.BeanOf[Storage](ctx)
+	require.True(t, ok)
+	require.Equal(t, logger, storage.(*storageImpl).Logger)
+
+	_, ok = context.BeanOf[UserService](ctx)
+	require.False(t, ok)
+	require.Panics(t, func() {
+		context.MustBeanOf[UserService](ctx)
+	})
+
+}
+
+func TestChecked(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx := context.Checked(context.Create(&configStorage{}))
+	require.NotNil(t, ctx)
+	require.Equal(t, 1, len(ctx.Core()))
+
+	require.Panics(t, func() {
+		context.Checked(context.Create(&userServiceImpl{}))
+	})
+
+}
+
+type pluginHandler[T any] struct {
+	calls int
+}
+
+func (t *pluginHandler[T]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	t.calls++
+}
+
+type pluginA struct{}
+type pluginB struct{}
+type pluginC struct{}
+type pluginD struct{}
+type pluginE struct{}
+
+func TestBeansImplementing(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create(
+		&pluginHandler[pluginA]{},
+		&pluginHandler[pluginB]{},
+		&pluginHandler[pluginC]{},
+		&pluginHandler[pluginD]{},
+		&pluginHandler[pluginE]{},
+		&configStorage{},
+	)
+	require.Nil(t, err)
+
+	handlers := context.BeansImplementing[http.Handler](ctx)
+	require.Equal(t, 5, len(handlers))
+	for _, h := range handlers {
+		require.NotNil(t, h)
+	}
+
+	require.Equal(t, 1, len(ctx.FindAll(StorageClass)))
+	require.Empty(t, context.BeansImplementing[UserService](ctx))
+
+}
+
+func TestLookupFirstOf(t *testing.T) {
+
+	context.Verbose = false
+
+	first := &pluginHandler[pluginB]{}
+	ctx, err := context.Create(
+		&configStorage{},
+		first,
+		&pluginHandler[pluginA]{},
+	)
+	require.Nil(t, err)
+
+	handler, ok := context.LookupFirstOf[http.Handler](ctx)
+	require.True(t, ok)
+	require.Equal(t, http.Handler(first), handler)
+
+	_, ok = context.BeanOf[http.Handler](ctx)
+	require.False(t, ok)
+
+	_, ok = context.LookupFirstOf[UserService](ctx)
+	require.False(t, ok)
+
+}
+
+type reportExporter struct {
+	Storage Storage `inject`
+	format  string
+}
+
+func TestLookupOrCreate(t *testing.T) {
+
+	context.Verbose = false
+
+	existing := &reportExporter{format: "json"}
+	ctx, err := context.Create(&configStorage{}, existing)
+	require.Nil(t, err)
+
+	exporter := context.LookupOrCreate(ctx, func() *reportExporter {
+		t.Fatal("factory must not be called for registered bean")
+		return nil
+	})
+	require.Equal(t, existing, exporter)
+
+	ctx, err = context.Create(&configStorage{})
+	require.Nil(t, err)
+
+	var calls int32
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := make([]*reportExporter, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = context.LookupOrCreate(ctx, func() *reportExporter {
+				mu.Lock()
+				calls++
+				mu.Unlock()
+				return &reportExporter{format: "csv"}
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(1), calls)
+	for _, r := range results {
+		require.Equal(t, results[0], r)
+	}
+	require.Equal(t, "csv", results[0].format)
+	require.NotNil(t, results[0].Storage)
+
+	_, ok := ctx.Bean(reflect.TypeOf(results[0]))
+	require.False(t, ok)
+
+}
+
+func TestLookupOrCreateRetry(t *testing.T) {
+
+	context.Verbose = false
+
+	// injection fails until Storage is provided
+	ctx, err := context.Create()
+	require.Nil(t, err)
+
+	calls := 0
+	factory := func() *reportExporter {
+		calls++
+		return &reportExporter{format: "csv"}
+	}
+	require.Panics(t, func() {
+		context.LookupOrCreate(ctx, factory)
+	})
+	require.Nil(t, ctx.Provide(&configStorage{}))
+	exporter := context.LookupOrCreate(ctx, factory)
+	require.NotNil(t, exporter.Storage)
+	require.Equal(t, exporter, context.LookupOrCreate(ctx, factory))
+	require.Equal(t, 2, calls)
+
+	// chain of contexts creates the object once
+	first, err := context.Create(&configStorage{})
+	require.Nil(t, err)
+	second, err := context.Create()
+	require.Nil(t, err)
+	chain := context.Chain(first, second)
+	calls = 0
+	exporter = context.LookupOrCreate(chain, factory)
+	require.Equal(t, exporter, context.LookupOrCreate(chain, factory))
+	require.Equal(t, 1, calls)
+
+}
@@ -0,0 +1,87 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"github.com/pkg/errors"
+	"reflect"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Group of independent contexts with collective lifecycle, for example services in a test harness.
+
+	Example:
+		g := context.NewContextGroup(usersCtx, ordersCtx, billingCtx)
+		defer g.Close()
+ */
+
+type ContextGroup struct {
+	contexts []Context
+}
+
+func NewContextGroup(ctxs ...Context) *ContextGroup {
+	return &ContextGroup{contexts: ctxs}
+}
+
+/**
+	Close all contexts in reverse order and collect all errors
+ */
+func (g *ContextGroup) Close() error {
+	var err []error
+	for i := len(g.contexts) - 1; i >= 0; i-- {
+		if e := g.contexts[i].Close(); e != nil {
+			err = append(err, e)
+		}
+	}
+	return multiple(err)
+}
+
+/**
+	Check that each type exists in at least one context of the group
+ */
+func (g *ContextGroup) Require(types ...reflect.Type) error {
+	for _, typ := range types {
+		found := false
+		for _, ctx := range g.contexts {
+			if _, ok := ctx.Bean(typ); ok {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.Errorf("bean '%v' not found in any context of the group", typ)
+		}
+	}
+	return nil
+}
+
+/**
+	Lookup registered beans by name in all contexts in order of the group
+ */
+func (g *ContextGroup) Lookup(name string) []interface{} {
+	var res []interface{}
+	for _, ctx := range g.contexts {
+		res = append(res, ctx.Lookup(name)...)
+	}
+	return res
+}
@@ -0,0 +1,65 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type groupResource struct {
+	name  string
+	order *[]string
+}
+
+func (t *groupResource) Destroy() error {
+	*t.order = append(*t.order, t.name)
+	return nil
+}
+
+func TestContextGroup(t *testing.T) {
+
+	context.Verbose = false
+	var order []string
+
+	var contexts []context.Context
+	for _, name := range []string{"users", "orders", "billing"} {
+		ctx, err := context.Create(&groupResource{name: name, order: &order}, &configStorage{}, context.Interface((*Storage)(nil)))
+		require.Nil(t, err)
+		contexts = append(contexts, ctx)
+	}
+
+	g := context.NewContextGroup(contexts...)
+
+	require.Nil(t, g.Require(StorageClass, reflect.TypeOf((*groupResource)(nil))))
+	require.NotNil(t, g.Require(UserServiceClass))
+
+	require.Equal(t, 3, len(g.Lookup("context_test.Storage")))
+	require.Equal(t, 0, len(g.Lookup("context_test.UserService")))
+
+	require.Nil(t, g.Close())
+	require.Equal(t, []string{"billing", "orders", "users"}, order)
+
+}
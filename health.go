@@ -0,0 +1,84 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+/**
+@author Alex Shvid
+*/
+
+const (
+	HealthUp      = "UP"
+	HealthDown    = "DOWN"
+	HealthUnknown = "UNKNOWN"
+)
+
+/**
+	Bean that reports its health, aggregated by Health() of the context
+ */
+type HealthIndicator interface {
+	Health() HealthStatus
+}
+
+type HealthStatus struct {
+
+	/**
+		HealthUp, HealthDown or custom status
+	 */
+	Status  string
+
+	/**
+		Free-form details of the status
+	 */
+	Details map[string]interface{}
+}
+
+type HealthReport struct {
+
+	/**
+		HealthDown if any indicator is down, HealthUp if all indicators are up, otherwise HealthUnknown
+	 */
+	Overall    string
+
+	/**
+		Status of each HealthIndicator bean by the bean name
+	 */
+	Indicators map[string]HealthStatus
+}
+
+func (t *context) Health() HealthReport {
+	report := HealthReport{
+		Overall:    HealthUp,
+		Indicators: make(map[string]HealthStatus),
+	}
+	for classPtr, b := range t.coreBeans() {
+		indicator, ok := b.obj.(HealthIndicator)
+		if !ok {
+			continue
+		}
+		status := indicator.Health()
+		report.Indicators[t.conf.beanNameStrategy(classPtr)] = status
+		switch {
+		case status.Status == HealthDown:
+			report.Overall = HealthDown
+		case status.Status != HealthUp && report.Overall == HealthUp:
+			report.Overall = HealthUnknown
+		}
+	}
+	return report
+}
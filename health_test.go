@@ -0,0 +1,67 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type databaseHealth struct{}
+
+func (t *databaseHealth) Health() context.HealthStatus {
+	return context.HealthStatus{Status: context.HealthUp}
+}
+
+type cacheHealth struct{}
+
+func (t *cacheHealth) Health() context.HealthStatus {
+	return context.HealthStatus{
+		Status:  context.HealthDown,
+		Details: map[string]interface{}{"error": "connection refused"},
+	}
+}
+
+func TestHealth(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create(
+		&databaseHealth{},
+		&cacheHealth{},
+		&configStorage{},
+	)
+	require.Nil(t, err)
+
+	report := ctx.Health()
+	require.Equal(t, context.HealthDown, report.Overall)
+	require.Equal(t, 2, len(report.Indicators))
+	require.Equal(t, context.HealthUp, report.Indicators["*context_test.databaseHealth"].Status)
+	require.Equal(t, "connection refused", report.Indicators["*context_test.cacheHealth"].Details["error"])
+
+	ctx, err = context.Create(&databaseHealth{})
+	require.Nil(t, err)
+	require.Equal(t, context.HealthUp, ctx.Health().Overall)
+
+}
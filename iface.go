@@ -0,0 +1,57 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import "reflect"
+
+/**
+@author Alex Shvid
+*/
+
+type interfaceMarker struct {
+	/**
+		Interface type, nil if argument is not a pointer to interface
+	 */
+	ifaceType reflect.Type
+	/**
+		Type of the invalid argument
+	 */
+	invalid   reflect.Type
+}
+
+/**
+	Register interface in the context, so the implementation could be found by Lookup() with the interface name.
+	Replaces the construction with anonymous struct &struct{ app.UserService `inject` }{}
+
+	Example:
+		ctx, err := context.Create(
+			&userServiceImpl{},
+			context.Interface((*app.UserService)(nil)),
+		)
+
+		beans := ctx.Lookup("app.UserService")
+ */
+
+func Interface(iface interface{}) interfaceMarker {
+	typ := reflect.TypeOf(iface)
+	if typ == nil || typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Interface {
+		return interfaceMarker{invalid: typ}
+	}
+	return interfaceMarker{ifaceType: typ.Elem()}
+}
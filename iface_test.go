@@ -0,0 +1,73 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"log"
+	"os"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestInterface(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+
+	ctx, err := context.Create(
+		logger,
+		&storageImpl{},
+		&configServiceImpl{},
+		&userServiceImpl{},
+		context.Interface((*UserService)(nil)),
+	)
+	require.Nil(t, err)
+	require.Equal(t, 4, len(ctx.Core()))
+
+	beans := ctx.Lookup("context_test.UserService")
+	require.Equal(t, 1, len(beans))
+	require.Equal(t, ctx.MustBean(UserServiceClass), beans[0])
+
+}
+
+func TestInterfaceInvalid(t *testing.T) {
+
+	context.Verbose = false
+
+	_, err := context.Create(context.Interface(&storageImpl{}))
+	require.NotNil(t, err)
+
+	_, err = context.Create(context.Interface(nil))
+	require.NotNil(t, err)
+
+}
+
+func TestInterfaceMissingImplementation(t *testing.T) {
+
+	context.Verbose = false
+
+	_, err := context.Create(context.Interface((*UserService)(nil)))
+	require.NotNil(t, err)
+
+}
@@ -0,0 +1,169 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type initStep struct {
+	/**
+		Bean to initialize
+	 */
+	bean     *bean
+
+	/**
+		Dependency path from the root of the injection tree to the bean, without the bean itself
+	 */
+	beanPath []*bean
+}
+
+/**
+	Order beans in core, so dependencies are going before beans that require them.
+	Beans in a dependency cycle are ordered by the first visit.
+	Independent beans are ordered by the value of 'order' tag if enabled.
+ */
+func initOrder(core map[reflect.Type]*bean, ordered bool) []initStep {
+
+	sortList := sortBeans
+	if ordered {
+		sortList = sortBeansByOrder
+	}
+
+	required := make(map[*bean]bool)
+	var all []*bean
+	for _, b := range core {
+		all = append(all, b)
+		for _, d := range b.dependencies {
+			required[d] = true
+		}
+	}
+	sortList(all)
+
+	var roots []*bean
+	for _, b := range all {
+		if !required[b] {
+			roots = append(roots, b)
+		}
+	}
+
+	var order []initStep
+	visited := make(map[*bean]bool)
+	var visit func(b *bean, path []*bean)
+	visit = func(b *bean, path []*bean) {
+		if visited[b] {
+			return
+		}
+		visited[b] = true
+		deps := append([]*bean(nil), b.dependencies...)
+		sortList(deps)
+		next := append(append([]*bean(nil), path...), b)
+		for _, d := range deps {
+			// skip beans of the parent context
+			if core[d.beanDef.classPtr] == d {
+				visit(d, next)
+			}
+		}
+		order = append(order, initStep{bean: b, beanPath: path})
+	}
+
+	for _, b := range roots {
+		visit(b, nil)
+	}
+	// dependency cycles do not have roots
+	for _, b := range all {
+		visit(b, nil)
+	}
+	return order
+}
+
+func sortBeans(list []*bean) {
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].beanDef.classPtr.String() < list[j].beanDef.classPtr.String()
+	})
+}
+
+func sortBeansByOrder(list []*bean) {
+	sortBeans(list)
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].beanDef.order < list[j].beanDef.order
+	})
+}
+
+func (t *context) OrderedCore() []reflect.Type {
+	var list []reflect.Type
+	for _, step := range initOrder(t.coreBeans(), t.conf.startupOrder) {
+		list = append(list, step.bean.beanDef.classPtr)
+	}
+	return list
+}
+
+func (t *context) GroupBy(classifier func(reflect.Type) string) map[string][]interface{} {
+	groups := make(map[string][]interface{})
+	for _, step := range initOrder(t.coreBeans(), t.conf.startupOrder) {
+		key := classifier(step.bean.beanDef.classPtr)
+		groups[key] = append(groups[key], step.bean.exposed)
+	}
+	return groups
+}
+
+/**
+	Add dependency path to the error, for example
+	"initializing *app.userService (required by *app.handler via field 'UserService'): connection refused"
+ */
+func (t initStep) wrap(err error) error {
+	var out strings.Builder
+	fmt.Fprintf(&out, "initializing %v", t.bean.beanDef.classPtr)
+	if len(t.beanPath) > 0 {
+		out.WriteString(" (")
+		dependency := t.bean
+		for i := len(t.beanPath) - 1; i >= 0; i-- {
+			owner := t.beanPath[i]
+			if dependency != t.bean {
+				out.WriteString(", ")
+			}
+			fmt.Fprintf(&out, "required by %v via field '%s'", owner.beanDef.classPtr, owner.fieldNameOf(dependency))
+			dependency = owner
+		}
+		out.WriteString(")")
+	}
+	return errors.Wrap(err, out.String())
+}
+
+/**
+	Find the name of the inject field that holds the dependency
+ */
+func (t *bean) fieldNameOf(dependency *bean) string {
+	value := t.valuePtr.Elem()
+	for _, f := range t.beanDef.fields {
+		field := value.Field(f.fieldNum)
+		if field.CanInterface() && !field.IsNil() && field.Interface() == dependency.obj {
+			return f.fieldName
+		}
+	}
+	return "?"
+}
@@ -0,0 +1,210 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"errors"
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type chainA struct {
+	B *chainB `inject`
+}
+
+type chainB struct {
+	C *chainC `inject`
+}
+
+type chainC struct {
+	D *chainD `inject`
+}
+
+type chainD struct {
+	E *chainE `inject`
+}
+
+type chainE struct {
+}
+
+func (t *chainE) PostConstruct() error {
+	return errors.New("connection refused")
+}
+
+func TestPostConstructChain(t *testing.T) {
+
+	context.Verbose = false
+
+	_, err := context.Create(
+		&chainC{},
+		&chainA{},
+		&chainE{},
+		&chainD{},
+		&chainB{},
+	)
+	require.NotNil(t, err)
+
+	msg := err.Error()
+	require.True(t, strings.HasPrefix(msg, "initializing *context_test.chainE (required by *context_test.chainD via field 'E'"))
+	require.True(t, strings.HasSuffix(msg, ": connection refused"))
+
+	last := -1
+	for _, name := range []string{"chainE", "chainD", "chainC", "chainB", "chainA"} {
+		idx := strings.Index(msg, name)
+		require.True(t, idx > last, name)
+		last = idx
+	}
+
+}
+
+type initRecorder struct {
+	order []string
+}
+
+type initDependency struct {
+	recorder *initRecorder
+}
+
+func (t *initDependency) PostConstruct() error {
+	t.recorder.order = append(t.recorder.order, "dependency")
+	return nil
+}
+
+type initService struct {
+	Dependency *initDependency `inject`
+	recorder   *initRecorder
+}
+
+func (t *initService) PostConstruct() error {
+	t.recorder.order = append(t.recorder.order, "service")
+	return nil
+}
+
+func TestPostConstructOrder(t *testing.T) {
+
+	context.Verbose = false
+
+	for i := 0; i < 10; i++ {
+		recorder := &initRecorder{}
+		_, err := context.Create(
+			&initService{recorder: recorder},
+			&initDependency{recorder: recorder},
+		)
+		require.Nil(t, err)
+		require.Equal(t, []string{"dependency", "service"}, recorder.order)
+	}
+
+}
+
+type orderedA struct {
+	_ struct{} `order:"50"`
+}
+
+type orderedB struct {
+	_ struct{} `order:"100"`
+}
+
+type orderedC struct {
+	_ struct{} `order:"-50"`
+}
+
+type orderedD struct {
+}
+
+type orderedE struct {
+	_ struct{} `order:"200"`
+}
+
+func TestStartupOrderAnnotation(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.CreateWithOptions([]interface{}{
+		&orderedA{}, &orderedB{}, &orderedC{}, &orderedD{}, &orderedE{},
+	}, context.WithStartupOrderAnnotation())
+	require.Nil(t, err)
+
+	require.Equal(t, []reflect.Type{
+		reflect.TypeOf((*orderedC)(nil)),
+		reflect.TypeOf((*orderedD)(nil)),
+		reflect.TypeOf((*orderedA)(nil)),
+		reflect.TypeOf((*orderedB)(nil)),
+		reflect.TypeOf((*orderedE)(nil)),
+	}, ctx.OrderedCore())
+
+}
+
+type invalidOrder struct {
+	_ struct{} `order:"first"`
+}
+
+func TestStartupOrderInvalid(t *testing.T) {
+
+	context.Verbose = false
+
+	_, err := context.CreateWithOptions([]interface{}{&invalidOrder{}}, context.WithStartupOrderAnnotation())
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "invalid order")
+
+	_, err = context.Create(&invalidOrder{})
+	require.Nil(t, err)
+
+}
+
+type tierHandler struct {
+	UserService UserService `inject`
+}
+
+func TestGroupBy(t *testing.T) {
+
+	context.Verbose = false
+
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+	storage, config, user, handler := &storageImpl{}, &configServiceImpl{}, &userServiceImpl{}, &tierHandler{}
+
+	ctx, err := context.Create(handler, user, config, storage, logger)
+	require.Nil(t, err)
+
+	tiers := map[reflect.Type]string{
+		reflect.TypeOf(logger):  "infra",
+		reflect.TypeOf(storage): "infra",
+		reflect.TypeOf(config):  "service",
+		reflect.TypeOf(user):    "service",
+		reflect.TypeOf(handler): "handler",
+	}
+	groups := ctx.GroupBy(func(typ reflect.Type) string {
+		return tiers[typ]
+	})
+
+	require.Equal(t, map[string][]interface{}{
+		"infra":   {logger, storage},
+		"service": {config, user},
+		"handler": {handler},
+	}, groups)
+
+}
@@ -0,0 +1,148 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"unsafe"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	State of inject fields of the object, see Inspect()
+ */
+type InjectionReport struct {
+
+	/**
+		Class of the inspected object
+	 */
+	ClassType reflect.Type
+
+	/**
+		Inject fields in the order of declaration
+	 */
+	Fields    []FieldReport
+}
+
+type FieldReport struct {
+
+	/**
+		Name of the inject field
+	 */
+	Name         string
+
+	/**
+		Type of the inject field
+	 */
+	Type         reflect.Type
+
+	/**
+		Field is not nil
+	 */
+	Populated    bool
+
+	/**
+		Dynamic type of the value in the field, nil if not populated
+	 */
+	InjectedType reflect.Type
+
+	/**
+		Value of the field is a bean of this context
+	 */
+	InContext    bool
+}
+
+/**
+	Returns true if all inject fields are populated
+ */
+func (t InjectionReport) Complete() bool {
+	for _, f := range t.Fields {
+		if !f.Populated {
+			return false
+		}
+	}
+	return true
+}
+
+/**
+	Table of inject fields
+ */
+func (t InjectionReport) String() string {
+	var out strings.Builder
+	w := tabwriter.NewWriter(&out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "FIELD\tTYPE\tPOPULATED\tINJECTED\tIN CONTEXT\n")
+	for _, f := range t.Fields {
+		injected := "-"
+		if f.InjectedType != nil {
+			injected = f.InjectedType.String()
+		}
+		fmt.Fprintf(w, "%s\t%v\t%v\t%s\t%v\n", f.Name, f.Type, f.Populated, injected, f.InContext)
+	}
+	w.Flush()
+	return out.String()
+}
+
+func (t *context) Inspect(obj interface{}) (InjectionReport, error) {
+	if obj == nil {
+		return InjectionReport{}, errors.New("null obj is are not allowed")
+	}
+	classPtr := reflect.TypeOf(obj)
+	if classPtr.Kind() != reflect.Ptr || classPtr.Elem().Kind() != reflect.Struct {
+		return InjectionReport{}, errors.Errorf("non-pointer instances are not allowed, type %v", classPtr)
+	}
+	bd, err := t.cache(obj, classPtr)
+	if err != nil {
+		return InjectionReport{}, err
+	}
+	return inspectFields(t, obj, bd), nil
+}
+
+/**
+	Report inject fields of the object, injected values are searched in the context by BeanFor()
+ */
+func inspectFields(ctx Context, obj interface{}, bd *beanDef) InjectionReport {
+	classPtr := reflect.TypeOf(obj)
+	value := reflect.ValueOf(obj).Elem()
+	report := InjectionReport{ClassType: classPtr}
+	for _, inject := range bd.fields {
+		field := value.Field(inject.fieldNum)
+		if !field.CanInterface() {
+			field = reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+		}
+		f := FieldReport{
+			Name:      inject.fieldName,
+			Type:      inject.fieldType,
+			Populated: !field.IsNil(),
+		}
+		if f.Populated {
+			v := field.Interface()
+			f.InjectedType = reflect.TypeOf(v)
+			_, f.InContext = ctx.BeanFor(v)
+		}
+		report.Fields = append(report.Fields, f)
+	}
+	return report
+}
@@ -0,0 +1,103 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type inspectedHandler struct {
+	Storage       Storage       `inject`
+	ConfigService ConfigService `inject`
+	UserService   UserService   `inject`
+}
+
+func TestInspect(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create(
+		&configStorage{},
+		&configServiceImpl{},
+		&userServiceImpl{},
+	)
+	require.Nil(t, err)
+
+	handler := &inspectedHandler{}
+	_, err = ctx.Inject(handler)
+	require.Nil(t, err)
+
+	report, err := ctx.Inspect(handler)
+	require.Nil(t, err)
+	require.Equal(t, reflect.TypeOf(handler), report.ClassType)
+	require.True(t, report.Complete())
+	require.Equal(t, 3, len(report.Fields))
+	for _, f := range report.Fields {
+		require.True(t, f.Populated, f.Name)
+		require.True(t, f.InContext, f.Name)
+	}
+	require.Equal(t, "Storage", report.Fields[0].Name)
+	require.Equal(t, StorageClass, report.Fields[0].Type)
+	require.Equal(t, reflect.TypeOf(&configStorage{}), report.Fields[0].InjectedType)
+
+	_, err = ctx.Inspect(nil)
+	require.NotNil(t, err)
+
+}
+
+func TestInspectPartial(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create(
+		&configStorage{},
+		&configServiceImpl{},
+		&userServiceImpl{},
+	)
+	require.Nil(t, err)
+
+	handler := &inspectedHandler{
+		Storage: &configStorage{},
+	}
+
+	report, err := ctx.Inspect(handler)
+	require.Nil(t, err)
+	require.False(t, report.Complete())
+
+	require.True(t, report.Fields[0].Populated)
+	require.False(t, report.Fields[0].InContext)
+	require.False(t, report.Fields[1].Populated)
+	require.Nil(t, report.Fields[1].InjectedType)
+	require.False(t, report.Fields[2].Populated)
+
+	lines := strings.Split(strings.TrimSpace(report.String()), "\n")
+	require.Equal(t, 4, len(lines))
+	require.True(t, strings.HasPrefix(lines[0], "FIELD"))
+	require.True(t, strings.HasPrefix(lines[1], "Storage"))
+	require.Contains(t, lines[2], "-")
+
+}
@@ -0,0 +1,103 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	stdcontext "context"
+	"github.com/pkg/errors"
+)
+
+/**
+@author Alex Shvid
+*/
+
+const (
+	LifecyclePostConstruct = "PostConstruct"
+	LifecycleDestroy       = "Destroy"
+)
+
+/**
+	Call PostConstruct() if the object is initializing bean, returns false if it is not
+ */
+func (t *context) initBean(ctx stdcontext.Context, obj interface{}) (initialized bool, err error) {
+	switch b := obj.(type) {
+	case InitializingBean:
+		initialized, err = true, t.withPostConstructTimeout(ctx, obj, func(stdcontext.Context) error {
+			return t.invoke(obj, LifecyclePostConstruct, b.PostConstruct)
+		})
+	case ContextInitializingBean:
+		initialized, err = true, t.withPostConstructTimeout(ctx, obj, func(ctx stdcontext.Context) error {
+			return t.invoke(obj, LifecyclePostConstruct, func() error {
+				return b.PostConstruct(ctx)
+			})
+		})
+	}
+	if err == nil {
+		t.activateHandler(obj)
+	}
+	return initialized, err
+}
+
+func (t *context) destroyBean(b DisposableBean) error {
+	t.deactivateHandler(b)
+	return t.invoke(b, LifecycleDestroy, b.Destroy)
+}
+
+/**
+	Call lifecycle method and recover panic if enabled by WithPanicRecovery
+ */
+func (t *context) invoke(obj interface{}, phase string, fn func() error) (err error) {
+	if handler := t.conf.panicHandler; handler != nil {
+		defer func() {
+			if r := recover(); r != nil {
+				handler(obj, phase, r)
+				err = errors.Errorf("panic in %s of '%T': %v", phase, obj, r)
+			}
+		}()
+	}
+	return fn()
+}
+
+/**
+	Reinitialize beans in topological order without rewiring,
+	each initializing bean is destroyed first if it is also a disposable bean
+ */
+func (t *context) Refresh() error {
+	t.refreshMu.Lock()
+	defer t.refreshMu.Unlock()
+	var err []error
+	for _, step := range initOrder(t.coreBeans(), t.conf.startupOrder) {
+		obj := step.bean.obj
+		switch obj.(type) {
+		case InitializingBean, ContextInitializingBean:
+		default:
+			continue
+		}
+		if d, ok := obj.(DisposableBean); ok {
+			if e := t.destroyBean(d); e != nil {
+				err = append(err, step.wrap(e))
+				continue
+			}
+		}
+		if _, e := t.initBean(stdcontext.Background(), obj); e != nil {
+			err = append(err, step.wrap(e))
+		}
+	}
+	return multiple(err)
+}
@@ -0,0 +1,136 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type panicInitBean struct {
+}
+
+func (t *panicInitBean) PostConstruct() error {
+	panic("bad state")
+}
+
+type panicDestroyBean struct {
+}
+
+func (t *panicDestroyBean) Destroy() error {
+	panic("bad destroy")
+}
+
+type recoveredPanic struct {
+	bean      interface{}
+	phase     string
+	recovered interface{}
+}
+
+func TestPanicRecovery(t *testing.T) {
+
+	context.Verbose = false
+
+	var recovered []recoveredPanic
+	handler := func(bean interface{}, phase string, r interface{}) {
+		recovered = append(recovered, recoveredPanic{bean, phase, r})
+	}
+
+	bean := &panicInitBean{}
+	require.NotPanics(t, func() {
+		_, err := context.CreateWithOptions([]interface{}{bean}, context.WithPanicRecovery(handler))
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "bad state")
+	})
+	require.Equal(t, []recoveredPanic{{bean, context.LifecyclePostConstruct, "bad state"}}, recovered)
+
+	recovered = nil
+	destroyBean := &panicDestroyBean{}
+	ctx, err := context.CreateWithOptions([]interface{}{destroyBean}, context.WithPanicRecovery(handler))
+	require.Nil(t, err)
+	require.NotPanics(t, func() {
+		require.NotNil(t, ctx.Close())
+	})
+	require.Equal(t, []recoveredPanic{{destroyBean, context.LifecycleDestroy, "bad destroy"}}, recovered)
+
+}
+
+func TestPanicWithoutRecovery(t *testing.T) {
+
+	context.Verbose = false
+
+	require.Panics(t, func() {
+		context.Create(&panicInitBean{})
+	})
+
+}
+
+type reloadableConfig struct {
+	Source    *configSource `inject`
+	Value     string
+	destroyed int
+}
+
+type configSource struct {
+	value string
+}
+
+func (t *reloadableConfig) PostConstruct() error {
+	t.Value = t.Source.value
+	return nil
+}
+
+func (t *reloadableConfig) Destroy() error {
+	t.destroyed++
+	return nil
+}
+
+func TestRefresh(t *testing.T) {
+
+	context.Verbose = false
+	source := &configSource{value: "v1"}
+	config := &reloadableConfig{}
+
+	ctx, err := context.Create(source, config)
+	require.Nil(t, err)
+	require.Equal(t, "v1", config.Value)
+
+	source.value = "v2"
+	require.Nil(t, ctx.Refresh())
+	require.Equal(t, "v2", config.Value)
+	require.Equal(t, 1, config.destroyed)
+	require.True(t, source == config.Source)
+
+	done := make(chan error)
+	for i := 0; i < 4; i++ {
+		go func() {
+			done <- ctx.Refresh()
+		}()
+	}
+	for i := 0; i < 4; i++ {
+		require.Nil(t, <-done)
+	}
+	require.Equal(t, 5, config.destroyed)
+
+}
@@ -0,0 +1,86 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"github.com/pkg/errors"
+	"reflect"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Mapper transforms initialized beans before they are returned by the context.
+
+	Injections made on creation of context keep the original instances,
+	mapped instances are returned by Bean(), Lookup() and injected on runtime.
+ */
+
+type Mapper interface {
+
+	/**
+		Returns the instance to register instead of obj, or obj itself to keep it
+	 */
+	Map(obj interface{}) interface{}
+
+}
+
+/**
+	Add mapper applied to each bean after PostConstruct(), mappers are applied in the order of options
+ */
+func WithMapper(m Mapper) Option {
+	return func(conf *contextConfig) {
+		conf.mappers = append(conf.mappers, m)
+	}
+}
+
+/**
+	Apply mappers to all beans in core and validate that mapped instances implement all registered types
+ */
+func (t *context) applyMappers() error {
+	if len(t.conf.mappers) == 0 {
+		return nil
+	}
+	registered := make(map[*bean][]reflect.Type)
+	t.registry.RLock()
+	for typ, b := range t.registry.beansByType {
+		registered[b] = append(registered[b], typ)
+	}
+	t.registry.RUnlock()
+
+	for _, b := range t.coreBeans() {
+		exposed := b.obj
+		for _, m := range t.conf.mappers {
+			exposed = m.Map(exposed)
+		}
+		if exposed == nil {
+			return errors.Errorf("mapper returned nil for bean '%v'", b.beanDef.classPtr)
+		}
+		exposedType := reflect.TypeOf(exposed)
+		for _, typ := range registered[b] {
+			if !exposedType.AssignableTo(typ) {
+				return errors.Errorf("mapped bean '%v' of type '%v' does not implement registered type '%v'", b.beanDef.classPtr, exposedType, typ)
+			}
+		}
+		b.exposed = exposed
+	}
+	return nil
+}
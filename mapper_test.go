@@ -0,0 +1,130 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"log"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type timedStorage struct {
+	Storage
+	sync.Mutex
+	durations map[string][]time.Duration
+}
+
+func (t *timedStorage) record(method string, start time.Time) {
+	t.Lock()
+	defer t.Unlock()
+	t.durations[method] = append(t.durations[method], time.Since(start))
+}
+
+func (t *timedStorage) Load(key string) string {
+	defer t.record("Load", time.Now())
+	return t.Storage.Load(key)
+}
+
+func (t *timedStorage) Store(key, value string) {
+	defer t.record("Store", time.Now())
+	t.Storage.Store(key, value)
+}
+
+type timingMapper struct {
+}
+
+func (t timingMapper) Map(obj interface{}) interface{} {
+	if s, ok := obj.(Storage); ok {
+		return &timedStorage{Storage: s, durations: make(map[string][]time.Duration)}
+	}
+	return obj
+}
+
+func TestMapper(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+
+	storage := &storageImpl{}
+	ctx, err := context.CreateWithOptions([]interface{}{
+		logger,
+		storage,
+		&configServiceImpl{},
+		context.Interface((*Storage)(nil)),
+	}, context.WithMapper(timingMapper{}))
+	require.Nil(t, err)
+
+	bean := ctx.MustBean(StorageClass)
+	timed, ok := bean.(*timedStorage)
+	require.True(t, ok)
+	require.Equal(t, storage, timed.Storage)
+	require.Equal(t, bean, ctx.Lookup("context_test.Storage")[0])
+
+	timed.Store("key", "value")
+	require.Equal(t, "value", timed.Load("key"))
+	require.Equal(t, 1, len(timed.durations["Load"]))
+	require.Equal(t, 1, len(timed.durations["Store"]))
+
+	// logger is not mapped
+	require.Equal(t, logger, ctx.MustBean(context.TokenOf[*log.Logger]().Type()))
+
+}
+
+type invalidMapper struct {
+}
+
+func (t invalidMapper) Map(obj interface{}) interface{} {
+	return &struct{}{}
+}
+
+func TestMapperInvalid(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+
+	_, err := context.CreateWithOptions([]interface{}{
+		logger,
+		&storageImpl{},
+	}, context.WithMapper(invalidMapper{}))
+	require.NotNil(t, err)
+
+}
+
+func TestMapperInvalidDestroysInitialized(t *testing.T) {
+
+	context.Verbose = false
+	storage := &lifecycleStorage{}
+
+	_, err := context.CreateWithOptions([]interface{}{
+		storage,
+		context.Interface((*Storage)(nil)),
+	}, context.WithMapper(invalidMapper{}))
+	require.NotNil(t, err)
+	require.Equal(t, 1, storage.initialized)
+	require.Equal(t, 1, storage.destroyed)
+
+}
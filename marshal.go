@@ -0,0 +1,99 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"reflect"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type fieldJSON struct {
+	Name      string `json:"name"`
+	FieldType string `json:"fieldType"`
+}
+
+type beanJSON struct {
+	Type    string      `json:"type"`
+	Package string      `json:"package"`
+	Fields  []fieldJSON `json:"fields"`
+}
+
+type contextJSON struct {
+	Beans    []beanJSON        `json:"beans"`
+	Registry map[string]string `json:"registry"`
+	Phase    string            `json:"phase"`
+}
+
+/**
+	Serialize wiring of the context to JSON for dashboards and topology visualizers.
+
+	Example:
+		{
+			"beans": [{"type": "*app.userServiceImpl", "package": "app", "fields": [{"name": "Storage", "fieldType": "app.Storage"}]}],
+			"registry": {"app.Storage": "*app.storageImpl"},
+			"phase": "ready"
+		}
+ */
+func MarshalContext(ctx Context) ([]byte, error) {
+	if m, ok := ctx.(json.Marshaler); ok {
+		return m.MarshalJSON()
+	}
+	return nil, errors.Errorf("context '%T' does not support marshaling", ctx)
+}
+
+func (t *context) MarshalJSON() ([]byte, error) {
+	core := t.coreBeans()
+	view := contextJSON{
+		Beans:    []beanJSON{},
+		Registry: make(map[string]string),
+		Phase:    t.Phase().String(),
+	}
+	var classes []reflect.Type
+	for classPtr := range core {
+		classes = append(classes, classPtr)
+	}
+	sortTypes(classes)
+	for _, classPtr := range classes {
+		b := core[classPtr]
+		bean := beanJSON{
+			Type:   classPtr.String(),
+			Fields: []fieldJSON{},
+		}
+		if classPtr.Kind() == reflect.Ptr {
+			bean.Package = classPtr.Elem().PkgPath()
+		}
+		for _, f := range b.beanDef.fields {
+			bean.Fields = append(bean.Fields, fieldJSON{Name: f.fieldName, FieldType: f.fieldType.String()})
+		}
+		view.Beans = append(view.Beans, bean)
+	}
+	t.registry.RLock()
+	for name, beans := range t.registry.beansByName {
+		if len(beans) > 0 {
+			view.Registry[name] = beans[0].beanDef.classPtr.String()
+		}
+	}
+	t.registry.RUnlock()
+	return json.Marshal(view)
+}
@@ -0,0 +1,77 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"encoding/json"
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"log"
+	"os"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestMarshalContext(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+
+	ctx, err := context.Create(
+		logger,
+		&storageImpl{},
+		&configServiceImpl{},
+		&userServiceImpl{},
+		context.Interface((*UserService)(nil)),
+	)
+	require.Nil(t, err)
+
+	data, err := context.MarshalContext(ctx)
+	require.Nil(t, err)
+
+	var view map[string]interface{}
+	require.Nil(t, json.Unmarshal(data, &view))
+
+	require.Equal(t, "ready", view["phase"])
+
+	registry := view["registry"].(map[string]interface{})
+	require.Equal(t, "*context_test.storageImpl", registry["context_test.Storage"])
+	require.Equal(t, "*context_test.configServiceImpl", registry["context_test.ConfigService"])
+	require.Equal(t, "*context_test.userServiceImpl", registry["context_test.UserService"])
+	require.Equal(t, "*log.Logger", registry["*log.Logger"])
+
+	beans := view["beans"].([]interface{})
+	require.Equal(t, 4, len(beans))
+
+	userService := beans[2].(map[string]interface{})
+	require.Equal(t, "*context_test.userServiceImpl", userService["type"])
+	require.Equal(t, "github.com/consensusdb/context_test", userService["package"])
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"name": "Storage", "fieldType": "context_test.Storage"},
+		map[string]interface{}{"name": "ConfigService", "fieldType": "context_test.ConfigService"},
+	}, userService["fields"])
+
+	data, err = json.Marshal(ctx)
+	require.Nil(t, err)
+	require.Contains(t, string(data), "\"phase\":\"ready\"")
+
+}
@@ -0,0 +1,60 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"reflect"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func (t *context) BeanMetadata(typ reflect.Type) map[string]string {
+	if b, ok := t.getBean(typ); ok {
+		return copyMetadata(b.metadata)
+	}
+	return nil
+}
+
+func (t *context) FindByMetadata(key, value string) []interface{} {
+	var list []*bean
+	for _, b := range t.coreBeans() {
+		if v, ok := b.metadata[key]; ok && v == value {
+			list = append(list, b)
+		}
+	}
+	sortBeans(list)
+	var res []interface{}
+	for _, b := range list {
+		res = append(res, b.exposed)
+	}
+	return res
+}
+
+func copyMetadata(metadata map[string]string) map[string]string {
+	if metadata == nil {
+		return nil
+	}
+	c := make(map[string]string, len(metadata))
+	for k, v := range metadata {
+		c[k] = v
+	}
+	return c
+}
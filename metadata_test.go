@@ -0,0 +1,64 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type metaBean[N any] struct {
+	meta map[string]string
+}
+
+func (t *metaBean[N]) Metadata() map[string]string {
+	return t.meta
+}
+
+func TestMetadata(t *testing.T) {
+
+	context.Verbose = false
+
+	database := &metaBean[[1]int]{meta: map[string]string{"tier": "infrastructure", "team": "platform"}}
+	cache := &metaBean[[2]int]{meta: map[string]string{"tier": "infrastructure"}}
+	payments := &metaBean[[3]int]{meta: map[string]string{"tier": "service", "team": "payments"}}
+	api := &metaBean[[4]int]{meta: map[string]string{"tier": "web", "api-version": "v2"}}
+	plain := &metaBean[[5]int]{}
+
+	ctx, err := context.Create(database, cache, payments, api, plain)
+	require.Nil(t, err)
+
+	beans := ctx.FindByMetadata("tier", "infrastructure")
+	require.Equal(t, 2, len(beans))
+	require.Contains(t, beans, database)
+	require.Contains(t, beans, cache)
+
+	require.Equal(t, []interface{}{payments}, ctx.FindByMetadata("team", "payments"))
+	require.Equal(t, 0, len(ctx.FindByMetadata("tier", "unknown")))
+
+	require.Equal(t, map[string]string{"tier": "web", "api-version": "v2"}, ctx.BeanMetadata(reflect.TypeOf(api)))
+	require.Nil(t, ctx.BeanMetadata(reflect.TypeOf(plain)))
+
+}
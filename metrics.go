@@ -0,0 +1,64 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"reflect"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Collector of bean access frequency and injection times, see WithMetrics
+ */
+type Metrics interface {
+
+	/**
+		Called on each lookup of the bean by type
+	 */
+	RecordBeanAccess(typ reflect.Type, duration time.Duration)
+
+	/**
+		Called on each injection of the source bean in to the target class
+	 */
+	RecordInjection(target, source reflect.Type, duration time.Duration)
+}
+
+/**
+	Default metrics, does nothing
+ */
+type NoopMetrics struct{}
+
+func (NoopMetrics) RecordBeanAccess(typ reflect.Type, duration time.Duration) {
+}
+
+func (NoopMetrics) RecordInjection(target, source reflect.Type, duration time.Duration) {
+}
+
+/**
+	Report bean access and injections in to the metrics
+ */
+func WithMetrics(m Metrics) Option {
+	return func(conf *contextConfig) {
+		conf.metrics = m
+	}
+}
@@ -0,0 +1,94 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type recordedInjection struct {
+	target, source reflect.Type
+}
+
+type recordingMetrics struct {
+	sync.Mutex
+	access     []reflect.Type
+	injections []recordedInjection
+}
+
+func (t *recordingMetrics) RecordBeanAccess(typ reflect.Type, duration time.Duration) {
+	t.Lock()
+	defer t.Unlock()
+	t.access = append(t.access, typ)
+}
+
+func (t *recordingMetrics) RecordInjection(target, source reflect.Type, duration time.Duration) {
+	t.Lock()
+	defer t.Unlock()
+	t.injections = append(t.injections, recordedInjection{target, source})
+}
+
+type metricsHandler struct {
+	Storage     Storage     `inject`
+	UserService UserService `inject`
+}
+
+func TestMetrics(t *testing.T) {
+
+	context.Verbose = false
+
+	metrics := &recordingMetrics{}
+	ctx, err := context.CreateWithOptions(
+		[]interface{}{&configStorage{}, &configServiceImpl{}, &userServiceImpl{}},
+		context.WithMetrics(metrics),
+	)
+	require.Nil(t, err)
+
+	// configServiceImpl.Storage, userServiceImpl.Storage, userServiceImpl.ConfigService
+	require.Equal(t, 3, len(metrics.injections))
+	require.Equal(t, 0, len(metrics.access))
+
+	ctx.MustBean(StorageClass)
+	require.Equal(t, []reflect.Type{StorageClass}, metrics.access)
+
+	_, err = ctx.Inject(&metricsHandler{})
+	require.Nil(t, err)
+	require.Equal(t, 3, len(metrics.access))
+	require.Equal(t, 5, len(metrics.injections))
+	require.Equal(t, recordedInjection{reflect.TypeOf(&metricsHandler{}), reflect.TypeOf(&userServiceImpl{})}, metrics.injections[4])
+
+}
+
+func BenchmarkNoopMetricsBean(b *testing.B) {
+	ctx, err := context.Create(&configStorage{})
+	require.Nil(b, err)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ctx.Bean(StorageClass)
+	}
+}
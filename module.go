@@ -0,0 +1,130 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"github.com/pkg/errors"
+	"reflect"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Group of related beans that could be passed to Create() as a single argument.
+
+	Example:
+		func DatabaseModule() *context.Module {
+			return context.NewModule(&dataSource{}, &userRepository{})
+		}
+
+		ctx, err := context.Create(DatabaseModule(), &userService{})
+ */
+
+type Module struct {
+	beans    []interface{}
+	requires []*Module
+}
+
+func NewModule(beans ...interface{}) *Module {
+	return &Module{beans: beans}
+}
+
+/**
+	Declare modules that must be present in the same context.
+ */
+func (t *Module) Requires(modules ...*Module) *Module {
+	t.requires = append(t.requires, modules...)
+	return t
+}
+
+/**
+	Get list of beans in the module, nested modules are not expanded
+ */
+func (t *Module) Beans() []interface{} {
+	return t.beans
+}
+
+/**
+	Replace modules in the scan list by their beans, nested modules are expanded recursively
+ */
+func expandModules(scan []interface{}) ([]interface{}, []*Module) {
+	var beans []interface{}
+	var modules []*Module
+	for _, obj := range scan {
+		if m, ok := obj.(*Module); ok && m != nil {
+			nestedBeans, nestedModules := expandModules(m.beans)
+			beans = append(beans, nestedBeans...)
+			modules = append(modules, m)
+			modules = append(modules, nestedModules...)
+		} else {
+			beans = append(beans, obj)
+		}
+	}
+	return beans, modules
+}
+
+/**
+	Check that all beans of the required modules are present in the created context.
+	Called after factories, conditions and scan filters are applied, so the required beans are resolved the same way:
+	conditional beans that do not match and types rejected by scan filters are not required,
+	function factories require the bean type they create.
+ */
+func (t *Module) checkRequires(ctx *context) error {
+	condCtx := &conditionContext{conf: ctx.conf}
+	for classPtr := range ctx.core {
+		condCtx.classes = append(condCtx.classes, classPtr)
+	}
+	for _, required := range t.requires {
+		beans, _ := expandModules(required.beans)
+		for _, obj := range beans {
+			if c, ok := obj.(Conditional); ok {
+				if cond := c.Condition(); cond != nil && !cond.Matches(condCtx) {
+					continue
+				}
+			}
+			var present bool
+			var classPtr reflect.Type
+			switch o := obj.(type) {
+			case nil, interfaceMarker:
+				continue
+			case functionFactory:
+				classPtr = o.beanType
+				_, present = ctx.registry.findByType(classPtr)
+			case registeredBean:
+				if !ctx.conf.acceptType(reflect.TypeOf(o.registeredObject())) {
+					continue
+				}
+				classPtr = o.registeredType()
+				_, present = ctx.core[classPtr]
+			default:
+				classPtr = reflect.TypeOf(obj)
+				if !ctx.conf.acceptType(classPtr) {
+					continue
+				}
+				_, present = ctx.core[classPtr]
+			}
+			if !present {
+				return errors.Errorf("module requires bean '%v' that is not present in context", classPtr)
+			}
+		}
+	}
+	return nil
+}
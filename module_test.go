@@ -0,0 +1,173 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"log"
+	"os"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func DatabaseModule() *context.Module {
+	return context.NewModule(
+		log.New(os.Stderr, "context: ", log.LstdFlags),
+		&storageImpl{},
+	)
+}
+
+func ServiceModule(dbModule *context.Module) *context.Module {
+	return context.NewModule(
+		&configServiceImpl{},
+		&userServiceImpl{},
+	).Requires(dbModule)
+}
+
+func TestModules(t *testing.T) {
+
+	context.Verbose = false
+
+	dbModule := DatabaseModule()
+	ctx, err := context.Create(
+		dbModule,
+		ServiceModule(dbModule),
+		&struct{ UserService `inject` }{},
+	)
+	require.Nil(t, err)
+	require.Equal(t, 5, len(ctx.Core()))
+
+	storage := ctx.MustBean(StorageClass)
+	userService := ctx.MustBean(UserServiceClass).(*userServiceImpl)
+	require.Equal(t, storage, userService.Storage)
+	require.Equal(t, ctx.MustBean(ConfigServiceClass), userService.ConfigService)
+
+}
+
+type memoryStorage struct {
+	internal map[string]string
+}
+
+func (t *memoryStorage) Load(key string) string {
+	return t.internal[key]
+}
+
+func (t *memoryStorage) Store(key, value string) {
+	t.internal[key] = value
+}
+
+func TestModuleMissingRequired(t *testing.T) {
+
+	context.Verbose = false
+
+	/**
+		memoryStorage satisfies all injections, but DatabaseModule is required by ServiceModule
+	 */
+	_, err := context.Create(
+		&memoryStorage{internal: make(map[string]string)},
+		ServiceModule(DatabaseModule()),
+	)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "module requires bean")
+
+}
+
+func TestNestedModules(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create(
+		context.NewModule(DatabaseModule(), &configServiceImpl{}),
+		&userServiceImpl{},
+	)
+	require.Nil(t, err)
+	require.Equal(t, 4, len(ctx.Core()))
+
+}
+
+func TestScanFilter(t *testing.T) {
+
+	context.Verbose = false
+	loggerClass := reflect.TypeOf((*log.Logger)(nil))
+
+	module := context.NewModule(
+		log.New(os.Stderr, "context: ", log.LstdFlags),
+		&configStorage{},
+		&configServiceImpl{},
+	)
+
+	ctx, err := context.CreateWithOptions(
+		[]interface{}{module},
+		context.WithScanFilter(func(typ reflect.Type) bool {
+			return typ != loggerClass
+		}),
+	)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(ctx.Core()))
+	_, ok := ctx.Bean(loggerClass)
+	require.False(t, ok)
+
+	ctx, err = context.CreateWithOptions(
+		[]interface{}{module},
+		context.WithScanFilter(func(typ reflect.Type) bool {
+			return typ != loggerClass
+		}),
+		context.WithScanFilter(func(typ reflect.Type) bool {
+			return typ.Elem().Name() != "configServiceImpl"
+		}),
+	)
+	require.Nil(t, err)
+	require.Equal(t, []reflect.Type{reflect.TypeOf(&configStorage{})}, ctx.Core())
+
+}
+
+func TestModuleRequiresResolved(t *testing.T) {
+
+	context.Verbose = false
+	loggerClass := reflect.TypeOf((*log.Logger)(nil))
+
+	infraModule := context.NewModule(
+		log.New(os.Stderr, "context: ", log.LstdFlags),
+		&redisCache{},
+		context.Factory(StorageClass, func(ctx context.Context) (interface{}, error) {
+			return &configStorage{}, nil
+		}),
+	)
+
+	_, err := context.CreateWithOptions(
+		[]interface{}{infraModule, context.NewModule(&orderedD{}).Requires(infraModule)},
+		context.WithScanFilter(func(typ reflect.Type) bool {
+			return typ != loggerClass
+		}),
+	)
+	require.Nil(t, err)
+
+	_, err = context.Create(
+		context.NewModule(&orderedD{}).Requires(infraModule),
+		&configStorage{},
+	)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "*log.Logger")
+
+}
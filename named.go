@@ -0,0 +1,69 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"reflect"
+	"sort"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Bean in scan list registered under the name in addition to its type
+ */
+type namedBean struct {
+	name string
+	obj  interface{}
+}
+
+func (t namedBean) registeredObject() interface{} {
+	return t.obj
+}
+
+func (t namedBean) registeredType() reflect.Type {
+	return reflect.TypeOf(t.obj)
+}
+
+/**
+	Create context from beans keyed by name. Each bean is registered under its type and the name,
+	so it could be found by Lookup(name) and injected in to fields with tag `inject:"name"`.
+
+	Example:
+		ctx, err := context.NewContextFromMap(map[string]interface{}{
+			"storage": &storageImpl{},
+			"config":  &configServiceImpl{},
+		})
+
+		storage := ctx.Lookup("storage")
+ */
+func NewContextFromMap(beans map[string]interface{}, options ...Option) (Context, error) {
+	names := make([]string, 0, len(beans))
+	for name := range beans {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	scan := make([]interface{}, 0, len(beans))
+	for _, name := range names {
+		scan = append(scan, namedBean{name: name, obj: beans[name]})
+	}
+	return CreateWithOptions(scan, options...)
+}
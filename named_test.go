@@ -0,0 +1,98 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type namedConsumer struct {
+	Primary   Storage `inject:"primary"`
+	Secondary Storage `inject:"secondary"`
+	Cache     Storage `inject:"cache,optional"`
+}
+
+func TestNewContextFromMap(t *testing.T) {
+
+	context.Verbose = false
+
+	storage := &configStorage{}
+	config := &configServiceImpl{}
+
+	ctx, err := context.NewContextFromMap(map[string]interface{}{
+		"storage": storage,
+		"config":  config,
+		"user":    &userServiceImpl{},
+	})
+	require.Nil(t, err)
+
+	require.Equal(t, context.BeanSlice{storage}, ctx.Lookup("storage"))
+	require.Equal(t, context.BeanSlice{config}, ctx.Lookup("config"))
+
+	// interface fields are resolved by type
+	require.Equal(t, Storage(storage), config.Storage)
+	user := ctx.MustBean(UserServiceClass).(*userServiceImpl)
+	require.Equal(t, ConfigService(config), user.ConfigService)
+
+}
+
+func TestNamedInjection(t *testing.T) {
+
+	context.Verbose = false
+
+	primary := &configStorage{}
+	secondary := &memoryStorage{}
+	consumer := &namedConsumer{}
+
+	ctx, err := context.NewContextFromMap(map[string]interface{}{
+		"primary":   primary,
+		"secondary": secondary,
+		"consumer":  consumer,
+	})
+	require.Nil(t, err)
+	require.Equal(t, Storage(primary), consumer.Primary)
+	require.Equal(t, Storage(secondary), consumer.Secondary)
+	require.Nil(t, consumer.Cache)
+
+	runtime := &namedConsumer{}
+	injected, err := ctx.Inject(runtime)
+	require.Nil(t, err)
+	require.Equal(t, []string{"Primary", "Secondary"}, injected)
+	require.Equal(t, Storage(secondary), runtime.Secondary)
+
+	def, ok := ctx.GetBeanDefinition(reflect.TypeOf(consumer))
+	require.True(t, ok)
+	require.Equal(t, "primary", def.Fields[0].Qualifier)
+	require.True(t, def.Fields[2].Optional)
+
+	_, err = context.NewContextFromMap(map[string]interface{}{
+		"primary":  primary,
+		"consumer": &namedConsumer{},
+	})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "can not find bean named 'secondary'")
+
+}
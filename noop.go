@@ -0,0 +1,301 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"github.com/pkg/errors"
+	"os"
+	"reflect"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Context without beans and side effects, null object for tests and benchmarks that need a Context argument.
+	Bean() does not find anything, Inject() does not change the object, Close() returns nil.
+	Phase() is PhaseReady, Fork(), Clone() and With() return the same no-op context.
+ */
+type NoopContext struct{}
+
+func NewNoopContext() Context {
+	return NoopContext{}
+}
+
+/**
+	CreateWithOptions() ignores the scan list and returns NoopContext
+ */
+func WithNoOp() Option {
+	return func(conf *contextConfig) {
+		conf.noop = true
+	}
+}
+
+func (t NoopContext) Deadline() (deadline time.Time, ok bool) {
+	return
+}
+
+func (t NoopContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (t NoopContext) Err() error {
+	return nil
+}
+
+func (t NoopContext) Value(key interface{}) interface{} {
+	return nil
+}
+
+func (t NoopContext) Close() error {
+	return nil
+}
+
+func (t NoopContext) AsyncClose() <-chan error {
+	ch := make(chan error, 1)
+	ch <- nil
+	close(ch)
+	return ch
+}
+
+func (t NoopContext) NotifyOnClose(ch chan<- struct{}) {
+}
+
+func (t NoopContext) RemoveOnClose(ch chan<- struct{}) {
+}
+
+func (t NoopContext) GracefulClose(signals <-chan os.Signal, timeout time.Duration) error {
+	return nil
+}
+
+func (t NoopContext) Wait() error {
+	return nil
+}
+
+func (t NoopContext) Release(obj interface{}) error {
+	return nil
+}
+
+func (t NoopContext) Drain() error {
+	return nil
+}
+
+func (t NoopContext) DrainWithTimeout(d time.Duration) error {
+	return nil
+}
+
+func (t NoopContext) Freeze() {
+}
+
+func (t NoopContext) IsFrozen() bool {
+	return false
+}
+
+func (t NoopContext) Refresh() error {
+	return nil
+}
+
+func (t NoopContext) Core() []reflect.Type {
+	return nil
+}
+
+func (t NoopContext) BeanCount() int {
+	return 0
+}
+
+func (t NoopContext) LocalBeanCount() int {
+	return 0
+}
+
+func (t NoopContext) RequireExactly(types ...reflect.Type) error {
+	return nil
+}
+
+func (t NoopContext) OrderedCore() []reflect.Type {
+	return nil
+}
+
+func (t NoopContext) GroupBy(classifier func(reflect.Type) string) map[string][]interface{} {
+	return nil
+}
+
+func (t NoopContext) Bean(typ reflect.Type) (bean interface{}, ok bool) {
+	return
+}
+
+func (t NoopContext) MustBean(typ reflect.Type) interface{} {
+	return nil
+}
+
+func (t NoopContext) TryBean(typ reflect.Type) (interface{}, error) {
+	return nil, errors.Errorf("bean not found %v", typ)
+}
+
+func (t NoopContext) Resolve(typ reflect.Type) (resolved reflect.Type, via string, err error) {
+	return nil, "", errors.Errorf("bean '%v' not found", typ)
+}
+
+func (t NoopContext) BeanFor(instance interface{}) (reflect.Type, bool) {
+	return nil, false
+}
+
+func (t NoopContext) Lookup(iface string) BeanSlice {
+	return nil
+}
+
+func (t NoopContext) MustLookup(iface string) BeanSlice {
+	return nil
+}
+
+func (t NoopContext) SortedLookup(iface string, less func(a, b interface{}) bool) BeanSlice {
+	return nil
+}
+
+func (t NoopContext) FindAll(ifaceType reflect.Type) BeanSlice {
+	return nil
+}
+
+func (t NoopContext) BeanTypesImplementing(ifaceType reflect.Type) []reflect.Type {
+	return nil
+}
+
+func (t NoopContext) BeanNames() []string {
+	return nil
+}
+
+func (t NoopContext) LookupAllNames() map[string][]interface{} {
+	return nil
+}
+
+func (t NoopContext) Debug() bool {
+	return false
+}
+
+func (t NoopContext) Trace() Trace {
+	return nil
+}
+
+func (t NoopContext) Provide(beans ...interface{}) error {
+	return nil
+}
+
+func (t NoopContext) Inject(obj interface{}) (injected []string, err error) {
+	return
+}
+
+func (t NoopContext) Inspect(obj interface{}) (InjectionReport, error) {
+	return InjectionReport{}, nil
+}
+
+func (t NoopContext) Warmup(classes ...reflect.Type) error {
+	return nil
+}
+
+func (t NoopContext) Mock(typ reflect.Type, obj interface{}) error {
+	return nil
+}
+
+func (t NoopContext) AtomicReplace(expected, replacement interface{}) (bool, error) {
+	return false, nil
+}
+
+func (t NoopContext) RecreateBean(typ reflect.Type) error {
+	return nil
+}
+
+func (t NoopContext) Snapshot() ContextSnapshot {
+	return ContextSnapshot{}
+}
+
+func (t NoopContext) Restore(s ContextSnapshot) error {
+	return nil
+}
+
+func (t NoopContext) ExplainAmbiguity(ifaceType reflect.Type) string {
+	return ""
+}
+
+func (t NoopContext) Bindings() []Binding {
+	return nil
+}
+
+func (t NoopContext) Phase() Phase {
+	return PhaseReady
+}
+
+func (t NoopContext) Diff(other Context) WiringDiff {
+	return WiringDiff{}
+}
+
+func (t NoopContext) Stats() Stats {
+	return Stats{}
+}
+
+func (t NoopContext) Health() HealthReport {
+	return HealthReport{}
+}
+
+func (t NoopContext) Observe(observer ContextObserver) {
+}
+
+func (t NoopContext) Graph() Graph {
+	return Graph{}
+}
+
+func (t NoopContext) InjectionGraph() map[reflect.Type][]reflect.Type {
+	return nil
+}
+
+func (t NoopContext) Scan() []ScanResult {
+	return nil
+}
+
+func (t NoopContext) GetBeanDefinition(typ reflect.Type) (BeanDefinition, bool) {
+	return BeanDefinition{}, false
+}
+
+func (t NoopContext) BeanMetadata(typ reflect.Type) map[string]string {
+	return nil
+}
+
+func (t NoopContext) FindByMetadata(key, value string) []interface{} {
+	return nil
+}
+
+func (t NoopContext) ExtractBeans(pkg string) []interface{} {
+	return nil
+}
+
+func (t NoopContext) Fork(scan ...interface{}) (Context, error) {
+	return t, nil
+}
+
+func (t NoopContext) Clone() Context {
+	return t
+}
+
+func (t NoopContext) With(key, value interface{}) Context {
+	return t
+}
+
+func (t NoopContext) Unwrap() Context {
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestNoopContext(t *testing.T) {
+
+	ctx, err := context.CreateWithOptions([]interface{}{&configStorage{}}, context.WithNoOp())
+	require.Nil(t, err)
+	require.Equal(t, context.NewNoopContext(), ctx)
+
+	b, ok := ctx.Bean(StorageClass)
+	require.Nil(t, b)
+	require.False(t, ok)
+	require.Empty(t, ctx.Core())
+
+	holder := &struct{ Storage Storage `inject` }{}
+	injected, err := ctx.Inject(holder)
+	require.Nil(t, err)
+	require.Empty(t, injected)
+	require.Nil(t, holder.Storage)
+
+	require.Nil(t, ctx.Close())
+	require.Nil(t, <-ctx.AsyncClose())
+
+	value := reflect.ValueOf(ctx)
+	ctxType := reflect.TypeOf((*context.Context)(nil)).Elem()
+	for i := 0; i < ctxType.NumMethod(); i++ {
+		m := ctxType.Method(i)
+		method := value.MethodByName(m.Name)
+		args := make([]reflect.Value, m.Type.NumIn())
+		for j := range args {
+			args[j] = reflect.Zero(m.Type.In(j))
+		}
+		require.NotPanics(t, func() {
+			if m.Type.IsVariadic() {
+				method.CallSlice(args)
+			} else {
+				method.Call(args)
+			}
+		}, m.Name)
+	}
+
+}
+
+func BenchmarkNoopContextBean(b *testing.B) {
+	ctx := context.NewNoopContext()
+	for i := 0; i < b.N; i++ {
+		ctx.Bean(StorageClass)
+	}
+}
@@ -0,0 +1,53 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+/**
+@author Alex Shvid
+*/
+
+func (t *context) NotifyOnClose(ch chan<- struct{}) {
+	t.closeNotifyMu.Lock()
+	defer t.closeNotifyMu.Unlock()
+	t.closeNotify = append(t.closeNotify, ch)
+}
+
+func (t *context) RemoveOnClose(ch chan<- struct{}) {
+	t.closeNotifyMu.Lock()
+	defer t.closeNotifyMu.Unlock()
+	for i, c := range t.closeNotify {
+		if c == ch {
+			t.closeNotify = append(t.closeNotify[:i], t.closeNotify[i+1:]...)
+			return
+		}
+	}
+}
+
+/**
+	Close registered channels in order of registration and forget them, so repeated Close() does not panic
+ */
+func (t *context) closeNotifyChannels() {
+	t.closeNotifyMu.Lock()
+	list := t.closeNotify
+	t.closeNotify = nil
+	t.closeNotifyMu.Unlock()
+	for _, ch := range list {
+		close(ch)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type notifiedCloser struct {
+	channels  []chan struct{}
+	observed  []bool
+}
+
+func (t *notifiedCloser) Destroy() error {
+	for _, ch := range t.channels {
+		select {
+		case <-ch:
+			t.observed = append(t.observed, true)
+		case <-time.After(10 * time.Millisecond):
+			t.observed = append(t.observed, false)
+		}
+	}
+	return nil
+}
+
+func TestNotifyOnClose(t *testing.T) {
+
+	context.Verbose = false
+
+	first, second, removed := make(chan struct{}), make(chan struct{}), make(chan struct{})
+	closer := &notifiedCloser{channels: []chan struct{}{first, second}}
+
+	ctx, err := context.Create(closer)
+	require.Nil(t, err)
+
+	ctx.NotifyOnClose(first)
+	ctx.NotifyOnClose(removed)
+	ctx.NotifyOnClose(second)
+	ctx.RemoveOnClose(removed)
+
+	require.Nil(t, ctx.Close())
+	require.Equal(t, []bool{true, true}, closer.observed)
+
+	select {
+	case <-removed:
+		t.Fatal("removed channel must stay open")
+	default:
+	}
+
+	require.Nil(t, ctx.Close())
+
+}
@@ -0,0 +1,80 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"reflect"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Callbacks on lifecycle events of the context, push-based alternative to Stats() and Graph()
+ */
+type ContextObserver interface {
+
+	/**
+		Called on Create() for each bean in core in the order of scan list
+	 */
+	OnBeanRegistered(typ reflect.Type, bean interface{})
+
+	/**
+		Called on each injection in to the field of the target class on Create() and Inject()
+	 */
+	OnBeanInjected(target, source reflect.Type, field string)
+
+	/**
+		Called once when creation of the context succeeded
+	 */
+	OnContextReady()
+
+	/**
+		Called on Close() before destroying beans
+	 */
+	OnContextClosing()
+}
+
+/**
+	Register the observer of events before creation of the context
+ */
+func WithObserver(o ContextObserver) Option {
+	return func(conf *contextConfig) {
+		conf.observers = append(conf.observers, o)
+	}
+}
+
+/**
+	Register the observer of events on runtime, multi-threading safe
+ */
+func (t *context) Observe(observer ContextObserver) {
+	t.observersMu.Lock()
+	defer t.observersMu.Unlock()
+	t.observers = append(t.observers, observer)
+}
+
+func (t *context) notify(fn func(o ContextObserver)) {
+	t.observersMu.RLock()
+	observers := t.observers
+	t.observersMu.RUnlock()
+	for _, o := range observers {
+		fn(o)
+	}
+}
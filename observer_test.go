@@ -0,0 +1,84 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type recordingObserver struct {
+	registered []reflect.Type
+	injected   []string
+	ready      int
+	closing    int
+}
+
+func (t *recordingObserver) OnBeanRegistered(typ reflect.Type, bean interface{}) {
+	t.registered = append(t.registered, typ)
+}
+
+func (t *recordingObserver) OnBeanInjected(target, source reflect.Type, field string) {
+	t.injected = append(t.injected, target.String()+"."+field)
+}
+
+func (t *recordingObserver) OnContextReady() {
+	t.ready++
+}
+
+func (t *recordingObserver) OnContextClosing() {
+	t.closing++
+}
+
+func TestObserver(t *testing.T) {
+
+	context.Verbose = false
+
+	observer := &recordingObserver{}
+	ctx, err := context.CreateWithOptions(
+		[]interface{}{&configStorage{}, &configServiceImpl{}, &userServiceImpl{}},
+		context.WithObserver(observer),
+	)
+	require.Nil(t, err)
+
+	require.ElementsMatch(t, ctx.Core(), observer.registered)
+	require.Equal(t, reflect.TypeOf(&configStorage{}), observer.registered[0])
+	require.Equal(t, 1, observer.ready)
+	require.Equal(t, 3, len(observer.injected))
+
+	runtime := &recordingObserver{}
+	ctx.Observe(runtime)
+
+	_, err = ctx.Inject(&metricsHandler{})
+	require.Nil(t, err)
+	require.Equal(t, []string{"*context_test.metricsHandler.Storage", "*context_test.metricsHandler.UserService"}, runtime.injected)
+	require.Equal(t, 5, len(observer.injected))
+
+	require.Nil(t, ctx.Close())
+	require.Equal(t, 1, observer.closing)
+	require.Equal(t, 1, runtime.closing)
+	require.Equal(t, 1, observer.ready)
+
+}
@@ -0,0 +1,441 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	stdcontext "context"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Configuration of the context collected from options on creation
+ */
+type contextConfig struct {
+
+	/**
+		Value of Verbose on creation of context
+	 */
+	verbose bool
+
+	/**
+		Function that gives the lookup name of the bean registered under type
+	 */
+	beanNameStrategy func(reflect.Type) string
+
+	/**
+		Maximum number of concurrent Destroy() calls in Close()
+	 */
+	closeParallelism int
+
+	/**
+		Maximum duration of wiring and initialization in Create(), zero means no limit
+	 */
+	initTimeout time.Duration
+
+	/**
+		Mappers applied to the initialized beans in order
+	 */
+	mappers []Mapper
+
+	/**
+		Handler of panics in PostConstruct() and Destroy(), nil means panics are not recovered
+	 */
+	panicHandler func(bean interface{}, phase string, recovered interface{})
+
+	/**
+		Parent context to resolve beans not found in this context
+	 */
+	parent Context
+
+	/**
+		Sources of properties for fields with 'value' tag, searched in order
+	 */
+	propertySources []PropertySource
+
+	/**
+		Set fields with 'env' tag from environment variables
+	 */
+	environment bool
+
+	/**
+		Inject beans in to unexported fields through unsafe pointers
+	 */
+	unexportedFields bool
+
+	/**
+		Handling of the repeated instances of the same type in scan list
+	 */
+	duplicateStrategy DuplicateStrategy
+
+	/**
+		Capture wiring trace and dump it to os.Stderr if creation of context fails
+	 */
+	debugOnFailure bool
+
+	/**
+		Initialize independent beans in order of 'order' tag
+	 */
+	startupOrder bool
+
+	/**
+		Hooks called before and after creation of context
+	 */
+	beforeCreate []func(beans []interface{})
+	afterCreate  []func(ctx Context, elapsed time.Duration)
+
+	/**
+		Handling of dependency cycles between beans in core
+	 */
+	cyclePolicy CircularDependencyPolicy
+
+	/**
+		Active profiles for conditions of beans
+	 */
+	profiles []string
+
+	/**
+		Populate runtime cache on creation of context
+	 */
+	warmup bool
+
+	/**
+		Collector of bean access and injection times
+	 */
+	metrics Metrics
+
+	/**
+		Observers of lifecycle events of the context
+	 */
+	observers []ContextObserver
+
+	/**
+		Filters of scanned types, bean is registered only if all filters accept it
+	 */
+	scanFilters []func(reflect.Type) bool
+
+	/**
+		Converters of beans to interfaces they do not implement
+	 */
+	converters []TypeConverter
+
+	/**
+		Close parent context on Close(), set for stages of CreatePipeline()
+	 */
+	closeParent bool
+
+	/**
+		Check before initialization that beans of the pipeline stage do not inject each other, see CreatePipeline()
+	 */
+	pipelineStage bool
+	stage         int
+
+	/**
+		Number of goroutines investigating beans of scan list, sequential scan if less than two
+	 */
+	scanWorkers int
+
+	/**
+		Maximum duration of each PostConstruct() call, zero means no limit
+	 */
+	postConstructTimeout time.Duration
+
+	/**
+		Hooks called around each Inject() on runtime
+	 */
+	beforeInject []func(obj interface{}, bd BeanDefinition)
+	afterInject  []func(obj interface{}, bd BeanDefinition, injected []string)
+
+	/**
+		Log of bean access, nil if not enabled
+	 */
+	accessLog BeanAccessLog
+
+	/**
+		Create NoopContext instead of the context with beans
+	 */
+	noop bool
+
+	/**
+		Track disposable prototypes created by WireNew() and AutoWire() until Release(), see Drain()
+	 */
+	trackPrototypes bool
+
+	/**
+		Standard context the context is derived from, nil means context.Background()
+	 */
+	baseContext stdcontext.Context
+}
+
+/**
+	Option of the context that could be passed to CreateWithOptions().
+
+	Example:
+		ctx, err := context.CreateWithOptions(
+			[]interface{}{ &userService{} },
+			context.WithBeanNameStrategy(context.ShortNameStrategy),
+		)
+ */
+
+type Option func(*contextConfig)
+
+func newContextConfig(options []Option) *contextConfig {
+	conf := &contextConfig{
+		verbose:          Verbose,
+		beanNameStrategy: FullNameStrategy,
+		metrics:          NoopMetrics{},
+	}
+	for _, opt := range options {
+		opt(conf)
+	}
+	return conf
+}
+
+/**
+	Customize names of beans used in Lookup()
+ */
+func WithBeanNameStrategy(strategy func(reflect.Type) string) Option {
+	return func(conf *contextConfig) {
+		conf.beanNameStrategy = strategy
+	}
+}
+
+/**
+	Local package plus name of the type, for example 'app.UserService'. Default strategy.
+ */
+func FullNameStrategy(typ reflect.Type) string {
+	return typ.String()
+}
+
+/**
+	Name of the type without package, for example 'UserService' or '*Logger'
+ */
+func ShortNameStrategy(typ reflect.Type) string {
+	if typ.Kind() == reflect.Ptr && typ.Name() == "" {
+		return "*" + ShortNameStrategy(typ.Elem())
+	}
+	if name := typ.Name(); name != "" {
+		return name
+	}
+	return typ.String()
+}
+
+/**
+	Name of the type without package in snake case, for example 'user_service' or '*logger'
+ */
+func SnakeCaseStrategy(typ reflect.Type) string {
+	name := []rune(ShortNameStrategy(typ))
+	var out strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 && name[i-1] != '*' && (unicode.IsLower(name[i-1]) || i+1 < len(name) && unicode.IsLower(name[i+1])) {
+				out.WriteRune('_')
+			}
+			out.WriteRune(unicode.ToLower(r))
+		} else {
+			out.WriteRune(r)
+		}
+	}
+	return out.String()
+}
+
+/**
+	Close independent beans concurrently, running at most n Destroy() calls at the same time.
+	Beans are destroyed before their dependencies.
+ */
+func WithCloseParallelism(n int) Option {
+	return func(conf *contextConfig) {
+		conf.closeParallelism = n
+	}
+}
+
+/**
+	Limit total time of wiring and initialization of the context.
+	Beans implementing ContextInitializingBean receive the context cancelled on timeout.
+	On timeout Create() returns the error immediately, initialized beans are destroyed in background
+	after the running PostConstruct() returns.
+ */
+func WithInitTimeout(d time.Duration) Option {
+	return func(conf *contextConfig) {
+		conf.initTimeout = d
+	}
+}
+
+/**
+	Recover panics in PostConstruct() and Destroy() calls, pass them to the handler and convert to errors.
+	Phase is LifecyclePostConstruct or LifecycleDestroy.
+ */
+func WithPanicRecovery(handler func(bean interface{}, phase string, recovered interface{})) Option {
+	return func(conf *contextConfig) {
+		conf.panicHandler = handler
+	}
+}
+
+/**
+	Add source of properties for fields with 'value' tag, sources are searched in the order of options
+ */
+func WithPropertySource(source PropertySource) Option {
+	return func(conf *contextConfig) {
+		conf.propertySources = append(conf.propertySources, source)
+	}
+}
+
+/**
+	Set fields with 'env' tag from environment variables after injection of beans
+ */
+func WithEnvironmentVariables() Option {
+	return func(conf *contextConfig) {
+		conf.environment = true
+	}
+}
+
+/**
+	Allow injection of beans in to unexported fields tagged with 'inject'.
+
+	This bypasses encapsulation of Go by using unsafe pointers, therefore must be enabled explicitly.
+ */
+func WithUnexportedFieldInjection() Option {
+	return func(conf *contextConfig) {
+		conf.unexportedFields = true
+	}
+}
+
+/**
+	Strategy of handling the repeated instances of the same type in scan list
+ */
+
+type DuplicateStrategy int
+
+const (
+	/**
+		Return error on repeated instance, default
+	 */
+	FailOnDuplicate DuplicateStrategy = iota
+
+	/**
+		Ignore repeated instances, the first one stays in context
+	 */
+	KeepFirst
+
+	/**
+		Later instance silently replaces the earlier one,
+		useful to override default implementation of the module in tests
+	 */
+	KeepLast
+)
+
+func WithDuplicateResolution(strategy DuplicateStrategy) Option {
+	return func(conf *contextConfig) {
+		conf.duplicateStrategy = strategy
+	}
+}
+
+/**
+	Capture all wiring steps of Create() in a trace buffer and dump it to os.Stderr only if Create() returns an error.
+	The format of the trace is the same as the Verbose output.
+ */
+func WithDebugOnFailure() Option {
+	return func(conf *contextConfig) {
+		conf.debugOnFailure = true
+	}
+}
+
+/**
+	Initialize beans with lower value of 'order' tag first, beans without the tag have order 0.
+	Dependencies are always initialized before beans that require them.
+	The tag that is not an integer fails Create() only if this option is set.
+
+	Go does not have struct-level tags, therefore the tag is declared on a blank field.
+
+	Example:
+		type cache struct {
+			_ struct{} `order:"100"`
+		}
+ */
+func WithStartupOrderAnnotation() Option {
+	return func(conf *contextConfig) {
+		conf.startupOrder = true
+	}
+}
+
+/**
+	Call hook synchronously before wiring of context with the scan list
+ */
+func WithBeforeCreate(hook func(beans []interface{})) Option {
+	return func(conf *contextConfig) {
+		conf.beforeCreate = append(conf.beforeCreate, hook)
+	}
+}
+
+/**
+	Call hook synchronously after successful creation of context with the total duration of creation
+ */
+func WithAfterCreate(hook func(ctx Context, elapsed time.Duration)) Option {
+	return func(conf *contextConfig) {
+		conf.afterCreate = append(conf.afterCreate, hook)
+	}
+}
+
+/**
+	Call hook synchronously on each Inject() before injection of fields, for example for audit of request scope objects
+ */
+func WithBeforeInject(hook func(obj interface{}, bd BeanDefinition)) Option {
+	return func(conf *contextConfig) {
+		conf.beforeInject = append(conf.beforeInject, hook)
+	}
+}
+
+/**
+	Call hook synchronously on each successful Inject() with the names of injected fields
+ */
+func WithAfterInject(hook func(obj interface{}, bd BeanDefinition, injected []string)) Option {
+	return func(conf *contextConfig) {
+		conf.afterInject = append(conf.afterInject, hook)
+	}
+}
+
+/**
+	Skip beans in scan list, including beans of modules, which types are rejected by the filter.
+	Multiple filters are combined with AND logic.
+
+	Example:
+		context.WithScanFilter(func(typ reflect.Type) bool {
+			return typ != reflect.TypeOf((*log.Logger)(nil))
+		})
+ */
+func WithScanFilter(filter func(reflect.Type) bool) Option {
+	return func(conf *contextConfig) {
+		conf.scanFilters = append(conf.scanFilters, filter)
+	}
+}
+
+func (t *contextConfig) acceptType(typ reflect.Type) bool {
+	for _, filter := range t.scanFilters {
+		if !filter(typ) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,203 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"log"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestShortNameStrategy(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+
+	ctx, err := context.CreateWithOptions([]interface{}{
+		logger,
+		&storageImpl{},
+		&configServiceImpl{},
+		&userServiceImpl{},
+		&struct{ UserService `inject` }{},
+	}, context.WithBeanNameStrategy(context.ShortNameStrategy))
+	require.Nil(t, err)
+
+	beans := ctx.Lookup("UserService")
+	require.Equal(t, 1, len(beans))
+	require.Equal(t, ctx.MustBean(UserServiceClass), beans[0])
+	require.Equal(t, 1, len(ctx.Lookup("*Logger")))
+	require.Equal(t, 0, len(ctx.Lookup("context_test.UserService")))
+
+}
+
+type HTTPServerConfig interface{}
+
+func TestNameStrategies(t *testing.T) {
+
+	loggerClass := reflect.TypeOf((*log.Logger)(nil))
+	httpClass := reflect.TypeOf((*HTTPServerConfig)(nil)).Elem()
+
+	require.Equal(t, "context_test.UserService", context.FullNameStrategy(UserServiceClass))
+	require.Equal(t, "*log.Logger", context.FullNameStrategy(loggerClass))
+
+	require.Equal(t, "UserService", context.ShortNameStrategy(UserServiceClass))
+	require.Equal(t, "*Logger", context.ShortNameStrategy(loggerClass))
+
+	require.Equal(t, "user_service", context.SnakeCaseStrategy(UserServiceClass))
+	require.Equal(t, "*logger", context.SnakeCaseStrategy(loggerClass))
+	require.Equal(t, "http_server_config", context.SnakeCaseStrategy(httpClass))
+
+}
+
+type encapsulatedService struct {
+	storage  Storage  `inject`
+}
+
+func TestUnexportedFieldInjection(t *testing.T) {
+
+	context.Verbose = false
+
+	_, err := context.Create(&memoryStorage{}, &encapsulatedService{})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "is not public")
+
+	service := &encapsulatedService{}
+	_, err = context.CreateWithOptions([]interface{}{
+		&memoryStorage{},
+		service,
+	}, context.WithUnexportedFieldInjection())
+	require.Nil(t, err)
+	require.NotNil(t, service.storage)
+
+	require.Equal(t, "", service.storage.Load("k"))
+
+}
+
+func TestDuplicateResolution(t *testing.T) {
+
+	context.Verbose = false
+	first := &memoryStorage{}
+	second := &memoryStorage{}
+
+	_, err := context.Create(first, second)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "repeated instance")
+
+	_, err = context.CreateWithOptions([]interface{}{first, second}, context.WithDuplicateResolution(context.FailOnDuplicate))
+	require.NotNil(t, err)
+
+	ctx, err := context.CreateWithOptions([]interface{}{first, second}, context.WithDuplicateResolution(context.KeepFirst))
+	require.Nil(t, err)
+	require.Equal(t, 1, len(ctx.Core()))
+	require.True(t, first == ctx.MustBean(reflect.TypeOf(first)))
+
+	service := &encapsulatedService{}
+	ctx, err = context.CreateWithOptions([]interface{}{first, service, second},
+		context.WithDuplicateResolution(context.KeepLast),
+		context.WithUnexportedFieldInjection())
+	require.Nil(t, err)
+	require.Equal(t, 2, len(ctx.Core()))
+	require.True(t, second == ctx.MustBean(reflect.TypeOf(second)))
+	require.True(t, second == service.storage)
+
+}
+
+type slowStart struct {
+}
+
+func (t *slowStart) PostConstruct() error {
+	time.Sleep(time.Millisecond)
+	return nil
+}
+
+func TestCreateHooks(t *testing.T) {
+
+	context.Verbose = false
+
+	var scanned []interface{}
+	var elapsed time.Duration
+	var created context.Context
+
+	bean := &slowStart{}
+	ctx, err := context.CreateWithOptions([]interface{}{bean},
+		context.WithBeforeCreate(func(beans []interface{}) {
+			scanned = beans
+		}),
+		context.WithAfterCreate(func(ctx context.Context, d time.Duration) {
+			created, elapsed = ctx, d
+		}))
+	require.Nil(t, err)
+
+	require.Equal(t, []interface{}{bean}, scanned)
+	require.Equal(t, ctx, created)
+	require.True(t, elapsed >= time.Millisecond)
+
+}
+
+type injectAudit struct {
+	obj      interface{}
+	class    reflect.Type
+	injected []string
+}
+
+func TestInjectHooks(t *testing.T) {
+
+	context.Verbose = false
+
+	var before, after []injectAudit
+	ctx, err := context.CreateWithOptions([]interface{}{&configStorage{}, &configServiceImpl{}},
+		context.WithBeforeInject(func(obj interface{}, bd context.BeanDefinition) {
+			before = append(before, injectAudit{obj: obj, class: bd.ClassType})
+		}),
+		context.WithAfterInject(func(obj interface{}, bd context.BeanDefinition, injected []string) {
+			after = append(after, injectAudit{obj, bd.ClassType, injected})
+		}))
+	require.Nil(t, err)
+	require.Empty(t, before)
+
+	var handlers []interface{}
+	for i := 0; i < 5; i++ {
+		handler := &optionalHandler{}
+		_, err := ctx.Inject(handler)
+		require.Nil(t, err)
+		handlers = append(handlers, handler)
+	}
+
+	require.Equal(t, 5, len(before))
+	require.Equal(t, 5, len(after))
+	for i, handler := range handlers {
+		require.Equal(t, handler, before[i].obj)
+		require.Equal(t, reflect.TypeOf(handler), before[i].class)
+		require.Equal(t, injectAudit{handler, reflect.TypeOf(handler), []string{"Storage", "ConfigService"}}, after[i])
+	}
+
+	_, err = ctx.Inject(&struct{ UserService UserService `inject` }{})
+	require.NotNil(t, err)
+	require.Equal(t, 6, len(before))
+	require.Equal(t, 5, len(after))
+
+}
@@ -0,0 +1,84 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"reflect"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Attach parent context, beans not found in the child context are searched in the parent.
+	Close() of the child context does not close the parent.
+ */
+func WithParent(parent Context) Option {
+	return func(conf *contextConfig) {
+		conf.parent = parent
+	}
+}
+
+func (t *context) Fork(scan ...interface{}) (Context, error) {
+	child, err := CreateWithOptions(scan, WithParent(t))
+	if err == nil {
+		t.track(child)
+	}
+	return child, err
+}
+
+func (t *context) Unwrap() Context {
+	return t.parent
+}
+
+func (t *context) BeanCount() int {
+	if t.parent != nil {
+		return len(t.coreBeans()) + t.parent.BeanCount()
+	}
+	return len(t.coreBeans())
+}
+
+func (t *context) LocalBeanCount() int {
+	return len(t.coreBeans())
+}
+
+/**
+	Search bean in the parent context, beans of other Context implementations are wrapped
+ */
+func (t *context) parentBean(typ reflect.Type) (*bean, bool) {
+	switch p := t.parent.(type) {
+	case nil:
+		return nil, false
+	case *context:
+		return p.getBean(typ)
+	default:
+		if obj, ok := p.Bean(typ); ok {
+			return &bean{
+				obj:      obj,
+				exposed:  obj,
+				valuePtr: reflect.ValueOf(obj),
+				beanDef: &beanDef{
+					classPtr: reflect.TypeOf(obj),
+				},
+			}, true
+		}
+		return nil, false
+	}
+}
@@ -0,0 +1,125 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"log"
+	"os"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestForkUnwrap(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+
+	root, err := context.Create(logger, &storageImpl{})
+	require.Nil(t, err)
+	require.Nil(t, root.Unwrap())
+
+	service, err := root.Fork(&configServiceImpl{}, &userServiceImpl{})
+	require.Nil(t, err)
+
+	request, err := service.Fork(&requestScope{})
+	require.Nil(t, err)
+
+	require.Equal(t, 1, len(request.Core()))
+	require.Equal(t, service, request.Unwrap())
+	require.Equal(t, 2, len(request.Unwrap().Core()))
+	require.Equal(t, root, request.Unwrap().Unwrap())
+	require.Equal(t, 2, len(request.Unwrap().Unwrap().Core()))
+
+	// beans of the parent contexts are injected and visible
+	userService := service.MustBean(UserServiceClass).(*userServiceImpl)
+	require.Equal(t, root.MustBean(StorageClass), userService.Storage)
+	require.Equal(t, root.MustBean(StorageClass), request.MustBean(StorageClass))
+	require.Equal(t, userService, request.MustBean(UserServiceClass))
+
+	// parent does not see beans of the child
+	_, ok := root.Bean(UserServiceClass)
+	require.False(t, ok)
+
+}
+
+type parentResource struct {
+	destroyed bool
+}
+
+func (t *parentResource) Destroy() error {
+	t.destroyed = true
+	return nil
+}
+
+func TestWithParent(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+	resource := &parentResource{}
+
+	parent, err := context.Create(logger, &storageImpl{}, resource)
+	require.Nil(t, err)
+
+	child, err := context.CreateWithOptions([]interface{}{
+		&configServiceImpl{},
+		&userServiceImpl{},
+	}, context.WithParent(parent))
+	require.Nil(t, err)
+	require.Equal(t, parent, child.Unwrap())
+
+	storage := parent.MustBean(StorageClass)
+	userService := child.MustBean(UserServiceClass).(*userServiceImpl)
+	require.Equal(t, storage, userService.Storage)
+
+	bean, ok := child.Bean(StorageClass)
+	require.True(t, ok)
+	require.Equal(t, storage, bean)
+
+	// child does not close the parent
+	require.Nil(t, child.Close())
+	require.False(t, resource.destroyed)
+	require.Equal(t, context.PhaseReady, parent.Phase())
+
+	require.Nil(t, parent.Close())
+	require.True(t, resource.destroyed)
+
+}
+
+func TestBeanCount(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+
+	parent, err := context.Create(logger, &configStorage{}, &configServiceImpl{})
+	require.Nil(t, err)
+	require.Equal(t, 3, parent.BeanCount())
+	require.Equal(t, 3, parent.LocalBeanCount())
+
+	child, err := parent.Fork(&userServiceImpl{}, &parentResource{})
+	require.Nil(t, err)
+	require.Equal(t, 5, child.BeanCount())
+	require.Equal(t, 2, child.LocalBeanCount())
+	require.Equal(t, len(child.Core()), child.LocalBeanCount())
+
+}
@@ -0,0 +1,89 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	stdcontext "context"
+	"github.com/pkg/errors"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Create context in stages, beans of each stage could inject only beans of the previous stages.
+	Each stage is a child context of the previous one, Close() of the returned context closes all stages.
+	The stage is checked before PostConstruct() of its beans.
+
+	Example:
+		ctx, err := context.CreatePipeline(
+			[]interface{}{ logger, &configImpl{} },
+			[]interface{}{ &storageImpl{} },
+			[]interface{}{ &userServiceImpl{} },
+			[]interface{}{ &userHandler{} },
+		)
+ */
+func CreatePipeline(stages ...[]interface{}) (Context, error) {
+	var ctx *context
+	for i, stage := range stages {
+		options := []Option{withPipelineStage(i)}
+		if ctx != nil {
+			options = append(options, WithParent(ctx))
+		}
+		next, err := create(stage, newContextConfig(options), stdcontext.Background())
+		if err != nil {
+			if ctx != nil {
+				ctx.Close()
+			}
+			return nil, errors.Wrapf(err, "stage %d", i)
+		}
+		ctx = next
+	}
+	if ctx == nil {
+		return Create()
+	}
+	return ctx, nil
+}
+
+/**
+	Close parent context on Close() and check dependencies of the stage, used by stages of pipeline
+ */
+func withPipelineStage(stage int) Option {
+	return func(conf *contextConfig) {
+		conf.closeParent = true
+		conf.pipelineStage = true
+		conf.stage = stage
+	}
+}
+
+/**
+	Check that beans of the stage do not inject beans of the same stage
+ */
+func (t *context) checkStage(stage int) error {
+	core := t.coreBeans()
+	for _, b := range core {
+		for _, d := range b.dependencies {
+			if core[d.beanDef.classPtr] == d {
+				return errors.Errorf("bean '%v' in stage %d injects '%v' from the same stage", b.beanDef.classPtr, stage, d.beanDef.classPtr)
+			}
+		}
+	}
+	return nil
+}
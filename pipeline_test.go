@@ -0,0 +1,99 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type pipelineHandler struct {
+	UserService UserService `inject`
+}
+
+type pipelineInitBean struct {
+	ConfigService ConfigService `inject`
+	initialized   bool
+}
+
+func (t *pipelineInitBean) PostConstruct() error {
+	t.initialized = true
+	return nil
+}
+
+func TestCreatePipeline(t *testing.T) {
+
+	context.Verbose = false
+	resource := &parentResource{}
+
+	ctx, err := context.CreatePipeline(
+		[]interface{}{&configStorage{}, resource},
+		[]interface{}{&configServiceImpl{}},
+		[]interface{}{&userServiceImpl{}},
+		[]interface{}{&pipelineHandler{}},
+	)
+	require.Nil(t, err)
+
+	config := ctx.MustBean(ConfigServiceClass).(*configServiceImpl)
+	require.Equal(t, ctx.MustBean(StorageClass), config.Storage)
+	require.Equal(t, 1, ctx.LocalBeanCount())
+	require.Equal(t, 5, ctx.BeanCount())
+
+	require.Nil(t, ctx.Close())
+	require.True(t, resource.destroyed)
+
+}
+
+func TestCreatePipelineLayers(t *testing.T) {
+
+	context.Verbose = false
+
+	// stage 1 requires ConfigService from stage 2
+	_, err := context.CreatePipeline(
+		[]interface{}{&configStorage{}},
+		[]interface{}{&userServiceImpl{}},
+		[]interface{}{&configServiceImpl{}},
+	)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "stage 1")
+
+	// stage 1 injects bean from the same stage
+	_, err = context.CreatePipeline(
+		[]interface{}{&configStorage{}},
+		[]interface{}{&configServiceImpl{}, &userServiceImpl{}},
+	)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "from the same stage")
+
+	// stage is checked before initialization
+	bean := &pipelineInitBean{}
+	_, err = context.CreatePipeline(
+		[]interface{}{&configStorage{}},
+		[]interface{}{&configServiceImpl{}, bean},
+	)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "from the same stage")
+	require.False(t, bean.initialized)
+
+}
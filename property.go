@@ -0,0 +1,213 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"github.com/pkg/errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Source of configuration properties for fields with 'value' tag.
+
+	Example:
+		type server struct {
+			Port     int            `value:"${server.port:8080}"`
+			Timeout  time.Duration  `value:"${server.timeout}"`
+		}
+ */
+
+type PropertySource interface {
+
+	/**
+		Get property by key
+	 */
+	GetProperty(key string) (string, bool)
+
+}
+
+/**
+	Property source backed by map
+ */
+
+type MapPropertySource map[string]string
+
+func (t MapPropertySource) GetProperty(key string) (string, bool) {
+	value, ok := t[key]
+	return value, ok
+}
+
+var durationClass = reflect.TypeOf(time.Duration(0))
+
+type propertyDef struct {
+	/**
+		Class of that struct
+	*/
+	class        reflect.Type
+	/**
+		Field number of that struct
+	*/
+	fieldNum     int
+	/**
+		Field name where property is going to be set
+	*/
+	fieldName    string
+	/**
+		Type of the field
+	*/
+	fieldType    reflect.Type
+	/**
+		Key of the property
+	*/
+	key          string
+	/**
+		Default value if property is missing
+	*/
+	defaultValue string
+	hasDefault   bool
+	/**
+		Error of parsing the tag, returned on injection because the tag is ignored without property sources
+	*/
+	invalid      error
+}
+
+/**
+	Parse expression '${key}' or '${key:default}'
+ */
+func parseProperty(class reflect.Type, fieldNum int, field reflect.StructField, expr string) (*propertyDef, error) {
+	if !strings.HasPrefix(expr, "${") || !strings.HasSuffix(expr, "}") {
+		return nil, errors.Errorf("invalid value expression '%s' on field '%s' in class '%v', expected '${key}' or '${key:default}'", expr, field.Name, class)
+	}
+	def := &propertyDef{
+		class:     class,
+		fieldNum:  fieldNum,
+		fieldName: field.Name,
+		fieldType: field.Type,
+		key:       expr[2 : len(expr)-1],
+	}
+	if i := strings.IndexByte(def.key, ':'); i >= 0 {
+		def.key, def.defaultValue, def.hasDefault = def.key[:i], def.key[i+1:], true
+	}
+	if def.key == "" {
+		return nil, errors.Errorf("empty property key on field '%s' in class '%v'", field.Name, class)
+	}
+	if def.hasDefault {
+		if _, err := convertProperty(def.defaultValue, def.fieldType); err != nil {
+			return nil, errors.Errorf("invalid default value on field '%s' in class '%v', %v", field.Name, class, err)
+		}
+	}
+	return def, nil
+}
+
+/**
+	Set fields with 'value' tag, the tags are ignored if there are no property sources
+ */
+func injectProperties(value reflect.Value, bd *beanDef, sources []PropertySource) error {
+	if len(sources) == 0 {
+		return nil
+	}
+	for _, p := range bd.properties {
+		if err := p.inject(value, sources); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *propertyDef) inject(value reflect.Value, sources []PropertySource) error {
+	if t.invalid != nil {
+		return t.invalid
+	}
+	str, ok := t.lookup(sources)
+	if !ok {
+		return errors.Errorf("property '%s' not found for field '%s' in class '%v'", t.key, t.fieldName, t.class)
+	}
+	field := value.Field(t.fieldNum)
+	if !field.CanSet() {
+		return errors.Errorf("field '%s' in class '%v' is not public", t.fieldName, t.class)
+	}
+	converted, err := convertProperty(str, t.fieldType)
+	if err != nil {
+		return errors.Errorf("property '%s' for field '%s' in class '%v', %v", t.key, t.fieldName, t.class, err)
+	}
+	field.Set(converted)
+	return nil
+}
+
+func (t *propertyDef) lookup(sources []PropertySource) (string, bool) {
+	for _, source := range sources {
+		if str, ok := source.GetProperty(t.key); ok {
+			return str, true
+		}
+	}
+	return t.defaultValue, t.hasDefault
+}
+
+/**
+	Convert string to the value of the field type
+ */
+func convertProperty(str string, typ reflect.Type) (reflect.Value, error) {
+	value := reflect.New(typ).Elem()
+	if typ == durationClass {
+		d, err := time.ParseDuration(str)
+		if err != nil {
+			return value, err
+		}
+		value.SetInt(int64(d))
+		return value, nil
+	}
+	switch typ.Kind() {
+	case reflect.String:
+		value.SetString(str)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(str)
+		if err != nil {
+			return value, err
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(str, 10, typ.Bits())
+		if err != nil {
+			return value, err
+		}
+		value.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(str, 10, typ.Bits())
+		if err != nil {
+			return value, err
+		}
+		value.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(str, typ.Bits())
+		if err != nil {
+			return value, err
+		}
+		value.SetFloat(f)
+	default:
+		return value, errors.Errorf("unsupported property type '%v'", typ)
+	}
+	return value, nil
+}
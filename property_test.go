@@ -0,0 +1,99 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type serverConfig struct {
+	Host    string        `value:"${server.host}"`
+	Port    int           `value:"${server.port}"`
+	Secure  bool          `value:"${server.secure}"`
+	Timeout time.Duration `value:"${server.timeout:5s}"`
+	Workers uint8         `value:"${server.workers:4}"`
+}
+
+func TestPropertySource(t *testing.T) {
+
+	context.Verbose = false
+
+	source := context.MapPropertySource{
+		"server.host":    "localhost",
+		"server.port":    "8080",
+		"server.secure":  "true",
+		"server.workers": "16",
+	}
+
+	config := &serverConfig{}
+	_, err := context.CreateWithOptions([]interface{}{config}, context.WithPropertySource(source))
+	require.Nil(t, err)
+
+	require.Equal(t, "localhost", config.Host)
+	require.Equal(t, 8080, config.Port)
+	require.True(t, config.Secure)
+	require.Equal(t, 5*time.Second, config.Timeout)
+	require.Equal(t, uint8(16), config.Workers)
+
+}
+
+func TestPropertyMissing(t *testing.T) {
+
+	context.Verbose = false
+
+	source := context.MapPropertySource{
+		"server.host": "localhost",
+	}
+
+	_, err := context.CreateWithOptions([]interface{}{&serverConfig{}}, context.WithPropertySource(source))
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "server.port")
+
+}
+
+func TestPropertyInvalid(t *testing.T) {
+
+	context.Verbose = false
+
+	source := context.MapPropertySource{
+		"server.host":   "localhost",
+		"server.port":   "http",
+		"server.secure": "true",
+	}
+
+	_, err := context.CreateWithOptions([]interface{}{&serverConfig{}}, context.WithPropertySource(source))
+	require.NotNil(t, err)
+
+	invalid := &struct {
+		Port int `value:"server.port"`
+	}{}
+	_, err = context.CreateWithOptions([]interface{}{invalid}, context.WithPropertySource(source))
+	require.NotNil(t, err)
+
+	_, err = context.Create(invalid)
+	require.Nil(t, err)
+
+}
@@ -0,0 +1,117 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	stdcontext "context"
+	"github.com/pkg/errors"
+	"reflect"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func (t *context) Provide(beans ...interface{}) error {
+	if t.IsFrozen() {
+		return ErrContextFrozen
+	}
+	for i, obj := range beans {
+		var registeredType reflect.Type
+		if r, ok := obj.(registeredBean); ok {
+			obj, registeredType = r.registeredObject(), r.registeredType()
+		}
+		if obj == nil {
+			return errors.Errorf("null bean is not allowed on position %d", i)
+		}
+		classPtr := reflect.TypeOf(obj)
+		if classPtr.Kind() != reflect.Ptr {
+			return errors.Errorf("non-pointer instance is not allowed on position %d of type '%v'", i, classPtr)
+		}
+		if _, ok := t.coreBeans()[classPtr]; ok {
+			return errors.Errorf("repeated instance on position %d of type '%v'", i, classPtr)
+		}
+		if _, ok := t.providedBean(classPtr); ok {
+			return errors.Errorf("repeated instance on position %d of type '%v'", i, classPtr)
+		}
+		b, err := investigate(obj, classPtr)
+		if err != nil {
+			return err
+		}
+		if len(b.beanDef.fields) > 0 {
+			if _, err := t.Inject(obj); err != nil {
+				return errors.Wrapf(err, "provided bean '%v'", classPtr)
+			}
+		}
+		if _, err := t.initBean(stdcontext.Background(), obj); err != nil {
+			return errors.Wrapf(err, "provided bean '%v'", classPtr)
+		}
+		t.providedMu.Lock()
+		t.provided = append(t.provided, b)
+		t.providedMu.Unlock()
+		t.registry.addBean(classPtr, b)
+		if registeredType != nil && registeredType != classPtr {
+			t.registry.addBean(registeredType, b)
+		}
+		t.notify(func(o ContextObserver) {
+			o.OnBeanRegistered(classPtr, obj)
+		})
+	}
+	return nil
+}
+
+/**
+	Search bean registered by Provide() by class or interface, the interface must have a single implementation
+ */
+func (t *context) providedBean(ifaceType reflect.Type) (*bean, bool) {
+	t.providedMu.RLock()
+	defer t.providedMu.RUnlock()
+	var found *bean
+	for _, b := range t.provided {
+		if b.beanDef.classPtr == ifaceType {
+			return b, true
+		}
+		if b.beanDef.implements(ifaceType) {
+			if found != nil {
+				return nil, false
+			}
+			found = b
+		}
+	}
+	return found, found != nil
+}
+
+/**
+	Destroy beans registered by Provide() in reverse order of registration
+ */
+func (t *context) destroyProvided() []error {
+	t.providedMu.Lock()
+	list := t.provided
+	t.provided = nil
+	t.providedMu.Unlock()
+	var err []error
+	for i := len(list) - 1; i >= 0; i-- {
+		if d, ok := list[i].obj.(DisposableBean); ok {
+			if e := t.destroyBean(d); e != nil && !t.handleError(LifecycleDestroy, e) {
+				err = append(err, e)
+			}
+		}
+	}
+	return err
+}
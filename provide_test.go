@@ -0,0 +1,78 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type providedService struct {
+	Storage     Storage `inject`
+	initialized bool
+	destroyed   bool
+}
+
+func (t *providedService) PostConstruct() error {
+	t.initialized = true
+	return nil
+}
+
+func (t *providedService) Destroy() error {
+	t.destroyed = true
+	return nil
+}
+
+func (t *providedService) Get(key string) string {
+	return "provided"
+}
+
+func TestProvide(t *testing.T) {
+
+	context.Verbose = false
+
+	storage := &configStorage{}
+	ctx, err := context.Create(storage)
+	require.Nil(t, err)
+
+	service := &providedService{}
+	require.Nil(t, ctx.Provide(service))
+	require.True(t, service.initialized)
+	require.Equal(t, Storage(storage), service.Storage)
+
+	require.Equal(t, service, ctx.MustBean(reflect.TypeOf(service)))
+	require.Equal(t, service, ctx.MustBean(reflect.TypeOf((*optionalCache)(nil)).Elem()))
+	require.Equal(t, context.BeanSlice{service}, ctx.Lookup("*context_test.providedService"))
+
+	require.NotNil(t, ctx.Provide(&providedService{}))
+	require.NotNil(t, ctx.Provide(&configStorage{}))
+
+	ctx.Freeze()
+	require.Equal(t, context.ErrContextFrozen, ctx.Provide(&lruCache{}))
+
+	require.Nil(t, ctx.Close())
+	require.True(t, service.destroyed)
+
+}
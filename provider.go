@@ -0,0 +1,68 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"fmt"
+	"reflect"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Check if the type is a provider function like func() T, where T is a pointer or interface
+ */
+func isProvider(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Func || typ.NumIn() != 0 || typ.NumOut() != 1 {
+		return false
+	}
+	kind := typ.Out(0).Kind()
+	return kind == reflect.Ptr || kind == reflect.Interface
+}
+
+/**
+	Create provider function of the type func() T that searches the bean on each call.
+	The provider panics if the bean is not found, like MustBean().
+	If T is created by the non-singleton factory, the provider creates a new object on each call.
+ */
+func (t *context) provider(funcType reflect.Type) reflect.Value {
+	typ := funcType.Out(0)
+	if factory, ok := t.prototypes[typ]; ok {
+		return reflect.MakeFunc(funcType, func([]reflect.Value) []reflect.Value {
+			obj, err := t.newPrototype(factory, typ)
+			if err != nil {
+				panic(err.Error())
+			}
+			result := reflect.New(typ).Elem()
+			result.Set(reflect.ValueOf(obj))
+			return []reflect.Value{result}
+		})
+	}
+	return reflect.MakeFunc(funcType, func([]reflect.Value) []reflect.Value {
+		b, ok := t.getBean(typ)
+		if !ok {
+			panic(fmt.Sprintf("bean not found %v", typ))
+		}
+		result := reflect.New(typ).Elem()
+		result.Set(reflect.ValueOf(b.exposed))
+		return []reflect.Value{result}
+	})
+}
@@ -0,0 +1,78 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type storageClient struct {
+	Storage func() Storage `inject`
+}
+
+type userClient struct {
+	UserService func() UserService `inject`
+}
+
+func TestProviderInjection(t *testing.T) {
+
+	context.Verbose = false
+	storage := &configStorage{}
+	client := &storageClient{}
+
+	ctx, err := context.Create(storage, client)
+	require.Nil(t, err)
+	require.NotNil(t, client.Storage)
+
+	for i := 0; i < 3; i++ {
+		require.True(t, storage == client.Storage())
+	}
+
+	runtime := &storageClient{}
+	_, err = ctx.Inject(runtime)
+	require.Nil(t, err)
+	require.True(t, storage == runtime.Storage())
+
+	missing := &userClient{}
+	_, err = ctx.Inject(missing)
+	require.Nil(t, err)
+	require.Panics(t, func() {
+		missing.UserService()
+	})
+
+}
+
+type invalidProvider struct {
+	Storage func(name string) Storage `inject`
+}
+
+func TestProviderInvalid(t *testing.T) {
+
+	context.Verbose = false
+
+	_, err := context.Create(&invalidProvider{})
+	require.NotNil(t, err)
+
+}
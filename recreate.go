@@ -0,0 +1,88 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	stdcontext "context"
+	"github.com/pkg/errors"
+	"reflect"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Create a new zero-value instance of the bean class, inject fields on runtime, call PostConstruct(),
+	register it instead of the current bean in core and call Destroy() on the old instance.
+	Beans that got the old instance on creation of context keep it, Close() destroys the new instance.
+
+	Example:
+		err := ctx.RecreateBean(reflect.TypeOf((*configService)(nil)))
+ */
+func (t *context) RecreateBean(typ reflect.Type) error {
+	if t.IsFrozen() {
+		return ErrContextFrozen
+	}
+	old, ok := t.getBean(typ)
+	if !ok {
+		return errors.Errorf("bean '%v' not found", typ)
+	}
+	classPtr := old.beanDef.classPtr
+	if t.coreBeans()[classPtr] != old {
+		return errors.Errorf("bean '%v' is not in core of the context", classPtr)
+	}
+	if classPtr.Kind() != reflect.Ptr || classPtr.Elem().Kind() != reflect.Struct {
+		return errors.Errorf("bean '%v' is not a pointer to struct", classPtr)
+	}
+	valuePtr := reflect.New(classPtr.Elem())
+	obj := valuePtr.Interface()
+	if _, err := t.Inject(obj); err != nil {
+		return errors.Wrapf(err, "recreate bean '%v'", classPtr)
+	}
+	if _, err := t.initBean(stdcontext.Background(), obj); err != nil {
+		return errors.Wrapf(err, "recreate bean '%v'", classPtr)
+	}
+	b := &bean{
+		obj:          obj,
+		exposed:      obj,
+		valuePtr:     valuePtr,
+		beanDef:      old.beanDef,
+		dependencies: old.dependencies,
+		metadata:     old.metadata,
+		position:     old.position,
+		name:         old.name,
+	}
+
+	t.registry.Lock()
+	if t.core[classPtr] != old {
+		t.registry.Unlock()
+		if d, ok := obj.(DisposableBean); ok {
+			t.destroyBean(d)
+		}
+		return errors.Errorf("bean '%v' was replaced concurrently", classPtr)
+	}
+	t.replaceCore(old, b)
+	t.registry.Unlock()
+
+	if d, ok := old.obj.(DisposableBean); ok {
+		return t.destroyBean(d)
+	}
+	return nil
+}
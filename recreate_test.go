@@ -0,0 +1,96 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type CounterService interface {
+	Increment() int
+}
+
+type counterServiceImpl struct {
+	Storage     Storage `inject`
+	counter     int
+	initialized bool
+	destroyed   int
+}
+
+func (t *counterServiceImpl) Increment() int {
+	t.counter++
+	return t.counter
+}
+
+func (t *counterServiceImpl) PostConstruct() error {
+	t.initialized = true
+	return nil
+}
+
+func (t *counterServiceImpl) Destroy() error {
+	t.destroyed++
+	return nil
+}
+
+var CounterServiceClass = reflect.TypeOf((*CounterService)(nil)).Elem()
+
+type Incrementer interface {
+	Increment() int
+}
+
+var IncrementerClass = reflect.TypeOf((*Incrementer)(nil)).Elem()
+
+func TestRecreateBean(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create(&configStorage{}, &counterServiceImpl{})
+	require.Nil(t, err)
+
+	old := ctx.MustBean(CounterServiceClass).(*counterServiceImpl)
+	old.Increment()
+	require.Equal(t, 2, old.Increment())
+
+	require.Nil(t, ctx.RecreateBean(CounterServiceClass))
+	require.Equal(t, 1, old.destroyed)
+
+	recreated := ctx.MustBean(CounterServiceClass).(*counterServiceImpl)
+	require.True(t, recreated != old)
+	require.Equal(t, 0, recreated.counter)
+	require.True(t, recreated.initialized)
+	require.Equal(t, ctx.MustBean(StorageClass), recreated.Storage)
+	require.Equal(t, recreated, ctx.MustBean(reflect.TypeOf(old)))
+
+	require.Equal(t, recreated, ctx.MustBean(IncrementerClass))
+	require.Equal(t, context.BeanSlice{recreated}, ctx.FindAll(IncrementerClass))
+
+	require.NotNil(t, ctx.RecreateBean(UserServiceClass))
+
+	require.Nil(t, ctx.Close())
+	require.Equal(t, 1, old.destroyed)
+	require.Equal(t, 1, recreated.destroyed)
+
+}
@@ -20,6 +20,7 @@ package context
 
 import (
 	"reflect"
+	"sort"
 	"sync"
 )
 
@@ -31,6 +32,18 @@ type registry struct {
 	sync.RWMutex
 	beansByName map[string][]*bean
 	beansByType map[reflect.Type]*bean
+	/**
+		Types cached on lookup from core, they are not part of the wiring
+	 */
+	lazyTypes   map[reflect.Type]bool
+	beanName    func(reflect.Type) string
+}
+
+func (t *registry) init(conf *contextConfig) {
+	t.beansByName = make(map[string][]*bean)
+	t.beansByType = make(map[reflect.Type]*bean)
+	t.lazyTypes = make(map[reflect.Type]bool)
+	t.beanName = conf.beanNameStrategy
 }
 
 func (t *registry) findByType(ifaceType reflect.Type) (*bean, bool)  {
@@ -45,17 +58,119 @@ func (t *registry) findByName(iface string) []interface{} {
 	defer t.RUnlock()
 	var res []interface{}
 	for _, b := range t.beansByName[iface] {
-		res = append(res, b.obj)
+		res = append(res, b.exposed)
+	}
+	return res
+}
+
+func (t *registry) findBeanByName(name string) (*bean, bool) {
+	t.RLock()
+	defer t.RUnlock()
+	if list := t.beansByName[name]; len(list) > 0 {
+		return list[0], true
+	}
+	return nil, false
+}
+
+/**
+	Register bean under the name given on creation of context
+ */
+func (t *registry) addName(name string, b *bean) {
+	t.Lock()
+	defer t.Unlock()
+	t.beansByName[name] = append(t.beansByName[name], b)
+}
+
+/**
+	Copy of all registered names with the beans, safe to modify by the caller
+ */
+func (t *registry) snapshotNames() map[string][]interface{} {
+	t.RLock()
+	defer t.RUnlock()
+	res := make(map[string][]interface{}, len(t.beansByName))
+	for name, list := range t.beansByName {
+		beans := make([]interface{}, len(list))
+		for i, b := range list {
+			beans[i] = b.exposed
+		}
+		res[name] = beans
+	}
+	return res
+}
+
+func (t *registry) names() []string {
+	t.RLock()
+	defer t.RUnlock()
+	res := make([]string, 0, len(t.beansByName))
+	for name := range t.beansByName {
+		res = append(res, name)
 	}
+	sort.Strings(res)
 	return res
 }
 
 func (t*registry) addBean(ifaceType reflect.Type, b *bean) {
 	t.Lock()
 	defer t.Unlock()
+	t.add(ifaceType, b)
+}
+
+/**
+	Same as addBean, but the lock is held by the caller
+ */
+func (t *registry) add(ifaceType reflect.Type, b *bean) {
+	delete(t.lazyTypes, ifaceType)
 	t.beansByType[ifaceType] = b
-	name := ifaceType.String()
+	name := t.beanName(ifaceType)
 	t.beansByName[name] = append(t.beansByName[name], b)
 }
 
+/**
+	Cache the type found by lookup in core, the lock is held by the caller
+ */
+func (t *registry) cache(ifaceType reflect.Type, b *bean) {
+	if _, ok := t.beansByType[ifaceType]; ok {
+		return
+	}
+	t.add(ifaceType, b)
+	t.lazyTypes[ifaceType] = true
+}
+
+/**
+	Types registered on creation of context or on runtime, without types cached on lookup
+ */
+func (t *registry) wiredTypes() map[reflect.Type]*bean {
+	t.RLock()
+	defer t.RUnlock()
+	res := make(map[reflect.Type]*bean, len(t.beansByType))
+	for typ, b := range t.beansByType {
+		if !t.lazyTypes[typ] {
+			res[typ] = b
+		}
+	}
+	return res
+}
+
+/**
+	Register the new bean instead of the old one under the type and its name
+ */
+func (t *registry) replaceBean(ifaceType reflect.Type, old, b *bean) {
+	t.Lock()
+	defer t.Unlock()
+	t.replace(ifaceType, old, b)
+}
 
+/**
+	Same as replaceBean, but the lock is held by the caller
+ */
+func (t *registry) replace(ifaceType reflect.Type, old, b *bean) {
+	t.beansByType[ifaceType] = b
+	name := t.beanName(ifaceType)
+	for i, e := range t.beansByName[name] {
+		if e == old {
+			t.beansByName[name][i] = b
+			return
+		}
+	}
+	t.beansByName[name] = append(t.beansByName[name], b)
+}
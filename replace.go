@@ -0,0 +1,91 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"github.com/pkg/errors"
+	"reflect"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Compare-and-swap of the registered bean. Replaces the bean in core and under all types where it is registered,
+	so interfaces resolved later return the replacement too. The replacement is done only if the current value is pointer-equal to expected,
+	the check and the swap are done under a single lock.
+	Injections made on creation of context are not changed. The replaced bean is not destroyed, Close() destroys the replacement instead.
+
+	Example:
+		swapped, err := ctx.AtomicReplace(current, &storageImpl{})
+ */
+func (t *context) AtomicReplace(expected, replacement interface{}) (bool, error) {
+	if t.IsFrozen() {
+		return false, ErrContextFrozen
+	}
+	if expected == nil || replacement == nil {
+		return false, errors.New("null beans are not allowed")
+	}
+	if !reflect.TypeOf(expected).Comparable() {
+		return false, errors.Errorf("bean '%T' is not comparable", expected)
+	}
+	t.registry.Lock()
+	defer t.registry.Unlock()
+
+	found := make(map[*bean][]reflect.Type)
+	for typ, b := range t.registry.beansByType {
+		if b.exposed == expected {
+			found[b] = append(found[b], typ)
+		}
+	}
+	for classPtr, b := range t.core {
+		if b.exposed == expected {
+			found[b] = append(found[b], classPtr)
+		}
+	}
+	if len(found) == 0 {
+		return false, nil
+	}
+
+	classPtr := reflect.TypeOf(replacement)
+	for _, types := range found {
+		for _, typ := range types {
+			if !classPtr.AssignableTo(typ) {
+				return false, errors.Errorf("replacement of type '%v' does not implement '%v'", classPtr, typ)
+			}
+		}
+	}
+	for old := range found {
+		beanDef := &beanDef{classPtr: classPtr}
+		if old.beanDef.classPtr == classPtr {
+			beanDef = old.beanDef
+		}
+		t.replaceCore(old, &bean{
+			obj:      replacement,
+			exposed:  replacement,
+			valuePtr: reflect.ValueOf(replacement),
+			beanDef:  beanDef,
+			metadata: old.metadata,
+			position: old.position,
+			name:     old.name,
+		})
+	}
+	return true, nil
+}
@@ -0,0 +1,92 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestAtomicReplace(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create(&configStorage{}, &configServiceImpl{})
+	require.Nil(t, err)
+
+	current := ctx.MustBean(StorageClass)
+
+	var wg sync.WaitGroup
+	results := make([]bool, 2)
+	replacements := []*configStorage{{}, {}}
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			swapped, err := ctx.AtomicReplace(current, replacements[i])
+			require.Nil(t, err)
+			results[i] = swapped
+		}(i)
+	}
+	wg.Wait()
+
+	require.True(t, results[0] != results[1])
+	winner := replacements[0]
+	if results[1] {
+		winner = replacements[1]
+	}
+	require.True(t, ctx.MustBean(StorageClass) == Storage(winner))
+
+	swapped, err := ctx.AtomicReplace(current, &configStorage{})
+	require.Nil(t, err)
+	require.False(t, swapped)
+
+	swapped, err = ctx.AtomicReplace(winner, &parentResource{})
+	require.NotNil(t, err)
+	require.False(t, swapped)
+	require.True(t, ctx.MustBean(StorageClass) == Storage(winner))
+
+}
+
+func TestAtomicReplaceLazyInterface(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create(&configStorage{})
+	require.Nil(t, err)
+
+	current := ctx.MustBean(reflect.TypeOf(&configStorage{}))
+	replacement := &configStorage{}
+
+	swapped, err := ctx.AtomicReplace(current, replacement)
+	require.Nil(t, err)
+	require.True(t, swapped)
+
+	require.True(t, ctx.MustBean(reflect.TypeOf(&configStorage{})) == replacement)
+	require.True(t, ctx.MustBean(StorageClass) == Storage(replacement))
+	require.Equal(t, context.BeanSlice{replacement}, ctx.FindAll(StorageClass))
+
+}
@@ -0,0 +1,68 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"github.com/pkg/errors"
+	"reflect"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func (t *context) RequireExactly(types ...reflect.Type) error {
+	core := t.coreBeans()
+	classes := make([]reflect.Type, 0, len(core))
+	for classPtr := range core {
+		classes = append(classes, classPtr)
+	}
+	return requireExactly(classes, types)
+}
+
+/**
+	Compare classes of beans in core with the expected types
+ */
+func requireExactly(classes []reflect.Type, types []reflect.Type) error {
+	core := typeSet(classes)
+	expected := typeSet(types)
+	var missing, unexpected []reflect.Type
+	for typ := range expected {
+		if !core[typ] {
+			missing = append(missing, typ)
+		}
+	}
+	for typ := range core {
+		if !expected[typ] {
+			unexpected = append(unexpected, typ)
+		}
+	}
+	sortTypes(missing)
+	sortTypes(unexpected)
+	switch {
+	case len(missing) > 0 && len(unexpected) > 0:
+		return errors.Errorf("missing beans %v and unexpected beans %v in context", missing, unexpected)
+	case len(missing) > 0:
+		return errors.Errorf("missing beans %v in context", missing)
+	case len(unexpected) > 0:
+		return errors.Errorf("unexpected beans %v in context", unexpected)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,54 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestRequireExactly(t *testing.T) {
+
+	context.Verbose = false
+
+	storageClass := reflect.TypeOf(&configStorage{})
+	configClass := reflect.TypeOf(&configServiceImpl{})
+	userClass := reflect.TypeOf(&userServiceImpl{})
+	counterClass := reflect.TypeOf(&counterServiceImpl{})
+
+	ctx, err := context.Create(&configStorage{}, &configServiceImpl{}, &userServiceImpl{})
+	require.Nil(t, err)
+
+	require.Nil(t, ctx.RequireExactly(storageClass, configClass, userClass))
+
+	err = ctx.RequireExactly(storageClass, configClass)
+	require.NotNil(t, err)
+	require.Equal(t, "unexpected beans [*context_test.userServiceImpl] in context", err.Error())
+
+	err = ctx.RequireExactly(storageClass, configClass, counterClass)
+	require.NotNil(t, err)
+	require.Equal(t, "missing beans [*context_test.counterServiceImpl] and unexpected beans [*context_test.userServiceImpl] in context", err.Error())
+
+}
@@ -0,0 +1,100 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"github.com/pkg/errors"
+	"reflect"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Explain how Bean() finds the bean of the type, in the same order of steps:
+	the bean registered in the registry under the type,
+	the bean in core with the same class (ResolutionDirectPointer),
+	the single bean in core implementing the interface (ResolutionInterfaceSearch),
+	beans registered by Provide() and the parent context.
+
+	The bean in the registry that differs from the one found in core, for example registered by Mock() or Provide(),
+	is reported as ResolutionNamedLookup. Unlike Bean() the registry is not changed.
+ */
+func (t *context) Resolve(typ reflect.Type) (resolved reflect.Type, via string, err error) {
+	if typ == nil {
+		return nil, "", errors.New("null type is not allowed")
+	}
+	direct, isDirect := t.coreBeans()[typ]
+	if isDirect && !reflect.TypeOf(direct.exposed).AssignableTo(typ) {
+		isDirect = false
+	}
+	found, searchErr := searchByInterface(typ, t.coreBeans())
+	if b, ok := t.registry.findByType(typ); ok {
+		switch {
+		case isDirect && b == direct:
+			via = ResolutionDirectPointer
+		case searchErr == nil && b == found:
+			via = ResolutionInterfaceSearch
+		default:
+			via = ResolutionNamedLookup
+		}
+		return b.beanDef.classPtr, via, nil
+	}
+	if isDirect {
+		return direct.beanDef.classPtr, ResolutionDirectPointer, nil
+	}
+	if searchErr == nil {
+		if !reflect.TypeOf(found.exposed).AssignableTo(typ) {
+			return nil, "", errors.Errorf("bean '%v' is not assignable to '%v'", found.beanDef.classPtr, typ)
+		}
+		return found.beanDef.classPtr, ResolutionInterfaceSearch, nil
+	}
+	if len(findCandidates(typ, t.coreBeans())) > 0 {
+		return nil, "", searchErr
+	}
+	if b, ok := t.providedBean(typ); ok {
+		return b.beanDef.classPtr, ResolutionNamedLookup, nil
+	}
+	if t.parent != nil {
+		return t.parent.Resolve(typ)
+	}
+	return nil, "", errors.Errorf("bean '%v' not found", typ)
+}
+
+/**
+	Find the bean of the type in the same order as Bean() without the parent context, nothing is cached in the registry
+ */
+func (t *context) resolveBean(typ reflect.Type) (*bean, bool) {
+	if b, ok := t.registry.findByType(typ); ok {
+		return b, true
+	}
+	core := t.coreBeans()
+	if b, ok := core[typ]; ok && reflect.TypeOf(b.exposed).AssignableTo(typ) {
+		return b, true
+	}
+	b, err := searchByInterface(typ, core)
+	if err == nil {
+		return b, reflect.TypeOf(b.exposed).AssignableTo(typ)
+	}
+	if len(findCandidates(typ, core)) == 0 {
+		return t.providedBean(typ)
+	}
+	return nil, false
+}
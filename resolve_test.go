@@ -0,0 +1,64 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestResolve(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create(&configStorage{}, &configServiceImpl{}, &lruCache{}, &diskCache{})
+	require.Nil(t, err)
+
+	resolved, via, err := ctx.Resolve(reflect.TypeOf(&configServiceImpl{}))
+	require.Nil(t, err)
+	require.Equal(t, reflect.TypeOf(&configServiceImpl{}), resolved)
+	require.Equal(t, context.ResolutionDirectPointer, via)
+
+	bindings := len(ctx.Bindings())
+	resolved, via, err = ctx.Resolve(ConfigServiceClass)
+	require.Nil(t, err)
+	require.Equal(t, reflect.TypeOf(&configServiceImpl{}), resolved)
+	require.Equal(t, context.ResolutionInterfaceSearch, via)
+	require.Equal(t, bindings, len(ctx.Bindings()))
+
+	require.Nil(t, ctx.Mock(StorageClass, &memoryStorage{}))
+	resolved, via, err = ctx.Resolve(StorageClass)
+	require.Nil(t, err)
+	require.Equal(t, reflect.TypeOf(&memoryStorage{}), resolved)
+	require.Equal(t, context.ResolutionNamedLookup, via)
+
+	_, _, err = ctx.Resolve(UserServiceClass)
+	require.NotNil(t, err)
+
+	_, _, err = ctx.Resolve(reflect.TypeOf((*optionalCache)(nil)).Elem())
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "two or more")
+
+}
@@ -0,0 +1,149 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"reflect"
+	"sync"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Inject field of the bean in core discovered on creation of context
+ */
+type ScanResult struct {
+
+	/**
+		Class of the bean
+	 */
+	BeanType     reflect.Type
+
+	/**
+		Name of the inject field
+	 */
+	Field        string
+
+	/**
+		Type of the inject field
+	 */
+	RequiredType reflect.Type
+
+	/**
+		Field type is an interface
+	 */
+	IsInterface  bool
+
+	/**
+		Field could stay nil if implementation not found
+	 */
+	IsOptional   bool
+}
+
+func (t *context) Scan() []ScanResult {
+	var list []*bean
+	for _, b := range t.coreBeans() {
+		list = append(list, b)
+	}
+	sortBeans(list)
+	var res []ScanResult
+	for _, b := range list {
+		for _, f := range b.beanDef.fields {
+			res = append(res, ScanResult{
+				BeanType:     b.beanDef.classPtr,
+				Field:        f.fieldName,
+				RequiredType: f.fieldType,
+				IsInterface:  f.fieldType.Kind() == reflect.Interface,
+				IsOptional:   f.optional,
+			})
+		}
+	}
+	return res
+}
+
+/**
+	Investigate beans of scan list in parallel, see WithConcurrentScan
+ */
+func WithConcurrentScan(workers int) Option {
+	return func(conf *contextConfig) {
+		conf.scanWorkers = workers
+	}
+}
+
+type investigation struct {
+	position int
+	bean     *bean
+	err      error
+}
+
+/**
+	Investigate structs in scan list by the pool of workers, results are indexed by position in scan list.
+	Objects that are not pointers to structs are left to the sequential scan that reports errors in order.
+ */
+func investigateConcurrently(scan []interface{}, workers int) []*investigation {
+	results := make([]*investigation, len(scan))
+	jobs := make(chan int)
+	out := make(chan *investigation)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				obj, classPtr := scanObject(scan[i])
+				b, err := investigate(obj, classPtr)
+				out <- &investigation{position: i, bean: b, err: err}
+			}
+		}()
+	}
+	go func() {
+		for i, obj := range scan {
+			if obj, classPtr := scanObject(obj); obj != nil && classPtr.Kind() == reflect.Ptr && classPtr.Elem().Kind() == reflect.Struct {
+				jobs <- i
+			}
+		}
+		close(jobs)
+		wg.Wait()
+		close(out)
+	}()
+	for r := range out {
+		results[r.position] = r
+	}
+	return results
+}
+
+/**
+	Object and its class in scan list, nil for interface markers and nil objects
+ */
+func scanObject(obj interface{}) (interface{}, reflect.Type) {
+	switch o := obj.(type) {
+	case nil, interfaceMarker:
+		return nil, nil
+	case registeredBean:
+		obj = o.registeredObject()
+		if obj == nil {
+			return nil, nil
+		}
+		return obj, o.registeredType()
+	default:
+		return obj, reflect.TypeOf(obj)
+	}
+}
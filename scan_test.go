@@ -0,0 +1,121 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"fmt"
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"log"
+	"os"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestScan(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+
+	ctx, err := context.Create(
+		logger,
+		&storageImpl{},
+		&configServiceImpl{},
+		&userServiceImpl{},
+	)
+	require.Nil(t, err)
+
+	userServiceClass := reflect.TypeOf(&userServiceImpl{})
+	var fields []context.ScanResult
+	for _, r := range ctx.Scan() {
+		if r.BeanType == userServiceClass {
+			fields = append(fields, r)
+		}
+	}
+
+	require.Equal(t, []context.ScanResult{
+		{BeanType: userServiceClass, Field: "Storage", RequiredType: StorageClass, IsInterface: true},
+		{BeanType: userServiceClass, Field: "ConfigService", RequiredType: ConfigServiceClass, IsInterface: true},
+	}, fields)
+
+	require.Equal(t, 4, len(ctx.Scan()))
+
+}
+
+/**
+	Instances of distinct struct types, each one injects Storage
+ */
+func generatedBeans(n int) []interface{} {
+	beans := []interface{}{&configStorage{}}
+	for i := 0; i < n; i++ {
+		class := reflect.StructOf([]reflect.StructField{
+			{Name: "Storage", Type: StorageClass, Tag: `inject`},
+			{Name: "Name", Type: reflect.TypeOf(""), Tag: reflect.StructTag(fmt.Sprintf(`bean:"%d"`, i))},
+		})
+		beans = append(beans, reflect.New(class).Interface())
+	}
+	return beans
+}
+
+func TestConcurrentScan(t *testing.T) {
+
+	context.Verbose = false
+
+	sequential, err := context.Create(generatedBeans(100)...)
+	require.Nil(t, err)
+
+	for _, workers := range []int{2, 8} {
+		beans := generatedBeans(100)
+		concurrent, err := context.CreateWithOptions(beans, context.WithConcurrentScan(workers))
+		require.Nil(t, err)
+		require.Empty(t, context.ContextDiff(sequential, concurrent))
+		require.Equal(t, len(sequential.Core()), len(concurrent.Core()))
+		require.Equal(t, concurrent.MustBean(StorageClass), reflect.ValueOf(beans[100]).Elem().Field(0).Interface())
+	}
+
+	_, err = context.CreateWithOptions(
+		[]interface{}{&configStorage{}, &struct{ Value int `inject` }{}},
+		context.WithConcurrentScan(4),
+	)
+	require.NotNil(t, err)
+
+}
+
+func benchmarkScan(b *testing.B, options ...context.Option) {
+	context.Verbose = false
+	beans := generatedBeans(500)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := context.CreateWithOptions(beans, options...); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkScanSequential(b *testing.B) {
+	benchmarkScan(b)
+}
+
+func BenchmarkScanConcurrent(b *testing.B) {
+	benchmarkScan(b, context.WithConcurrentScan(8))
+}
@@ -0,0 +1,63 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"reflect"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Returned by TryBean() while the context is in PhaseCreating and the bean is not registered yet,
+	for example the product of a factory that is not called yet. The caller could retry after creation completes.
+
+	Example:
+		if _, err := ctx.TryBean(typ); errors.As(err, &context.SentinelError{}) {
+			// retry later
+		}
+ */
+type SentinelError struct {
+	Type reflect.Type
+}
+
+func (e SentinelError) Error() string {
+	return fmt.Sprintf("bean '%v' is not registered yet, context is creating", e.Type)
+}
+
+/**
+	Check if the error is SentinelError, so the bean could appear later
+ */
+func IsSentinel(err error) bool {
+	return errors.As(err, &SentinelError{})
+}
+
+func (t *context) TryBean(typ reflect.Type) (interface{}, error) {
+	if b, ok := t.getBean(typ); ok {
+		return b.exposed, nil
+	}
+	if t.Phase() == PhaseCreating {
+		return nil, SentinelError{Type: typ}
+	}
+	return nil, errors.Errorf("bean not found %v", typ)
+}
@@ -0,0 +1,73 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type creatingContextHolder struct {
+	ctx context.Context
+	err error
+}
+
+func (t *creatingContextHolder) PostConstruct() error {
+	result := make(chan error)
+	go func() {
+		_, err := t.ctx.TryBean(UserServiceClass)
+		result <- err
+	}()
+	t.err = <-result
+	return nil
+}
+
+func TestSentinelError(t *testing.T) {
+
+	context.Verbose = false
+
+	holder := &creatingContextHolder{}
+	ctx, err := context.Create(
+		&configStorage{},
+		context.Factory(reflect.TypeOf(holder), func(ctx context.Context) (interface{}, error) {
+			holder.ctx = ctx
+			return holder, nil
+		}),
+	)
+	require.Nil(t, err)
+
+	require.NotNil(t, holder.err)
+	require.True(t, context.IsSentinel(holder.err))
+	require.Equal(t, context.SentinelError{Type: UserServiceClass}, holder.err)
+
+	_, err = ctx.TryBean(UserServiceClass)
+	require.NotNil(t, err)
+	require.False(t, context.IsSentinel(err))
+
+	storage, err := ctx.TryBean(StorageClass)
+	require.Nil(t, err)
+	require.NotNil(t, storage)
+
+}
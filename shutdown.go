@@ -0,0 +1,207 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	stdcontext "context"
+	"github.com/pkg/errors"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Close context on SIGINT or SIGTERM, the returned channel is closed when shutdown completes.
+	If the context is closed in another way, the signals are unregistered and the channel is closed.
+
+	Example:
+		ctx, err := context.Create(...)
+		done := context.ShutdownHook(ctx)
+		...
+		<-done
+ */
+func ShutdownHook(ctx Context) chan struct{} {
+	return shutdownHook(ctx, func() error {
+		return ctx.Close()
+	})
+}
+
+/**
+	Close context on SIGINT or SIGTERM, but do not wait longer than timeout for Close() to finish
+ */
+func ShutdownHookWithTimeout(ctx Context, timeout time.Duration) chan struct{} {
+	return shutdownHook(ctx, func() error {
+		goCtx, cancel := stdcontext.WithTimeout(stdcontext.Background(), timeout)
+		defer cancel()
+		return closeWithContext(goCtx, ctx)
+	})
+}
+
+func shutdownHook(ctx Context, closeFn func() error) chan struct{} {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		select {
+		case <-signals:
+			signal.Stop(signals)
+			closeFn()
+		case <-ctx.Done():
+			signal.Stop(signals)
+		}
+	}()
+	return done
+}
+
+/**
+	Call Close() and return error of goCtx if it is done before Close() finishes
+ */
+func closeWithContext(goCtx stdcontext.Context, ctx Context) error {
+	result := make(chan error, 1)
+	go func() {
+		result <- ctx.Close()
+	}()
+	select {
+	case err := <-result:
+		return err
+	case <-goCtx.Done():
+		return errors.Wrap(goCtx.Err(), "close interrupted")
+	}
+}
+
+func (t *context) GracefulClose(signals <-chan os.Signal, timeout time.Duration) error {
+	select {
+	case <-signals:
+	case <-t.Done():
+		return nil
+	}
+	goCtx, cancel := stdcontext.WithTimeout(stdcontext.Background(), timeout)
+	defer cancel()
+	return closeWithContext(goCtx, t)
+}
+
+/**
+	Bean that needs to finish in-flight work before the context is closed by Shutdown()
+ */
+type GracefulShutdown interface {
+
+	/**
+		Maximum duration of PreShutdown(), zero means no limit
+	 */
+	GracePeriod() time.Duration
+
+	/**
+		Called on signal before Close() of the context
+	 */
+	PreShutdown() error
+}
+
+/**
+	Close context on signals, SIGTERM and SIGINT by default.
+	Waits for PreShutdown() of all GracefulShutdown beans, then calls Close().
+	The returned channel receives the aggregated error or nil.
+
+	If the context is closed in another way, the signals are unregistered
+	and the channel receives the error of the context, that is context.Canceled.
+
+	Example:
+		ctx, err := context.Create(...)
+		if err := <-context.Shutdown(ctx); err != nil {
+			log.Printf("shutdown: %v", err)
+		}
+ */
+func Shutdown(ctx Context, signals ...os.Signal) <-chan error {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGTERM, os.Interrupt}
+	}
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, signals...)
+	done := make(chan error, 1)
+	go func() {
+		defer close(done)
+		select {
+		case <-ch:
+			signal.Stop(ch)
+		case <-ctx.Done():
+			signal.Stop(ch)
+			done <- ctx.Err()
+			return
+		}
+		err := preShutdown(ctx)
+		if e := ctx.Close(); e != nil {
+			err = append(err, e)
+		}
+		done <- multiple(err)
+	}()
+	return done
+}
+
+/**
+	Call PreShutdown() of all GracefulShutdown beans concurrently, each limited by its grace period
+ */
+func preShutdown(ctx Context) []error {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var err []error
+	for _, typ := range ctx.Core() {
+		obj, ok := ctx.Bean(typ)
+		if !ok {
+			continue
+		}
+		g, ok := obj.(GracefulShutdown)
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if e := callPreShutdown(g); e != nil {
+				mu.Lock()
+				err = append(err, e)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return err
+}
+
+func callPreShutdown(g GracefulShutdown) error {
+	period := g.GracePeriod()
+	if period <= 0 {
+		return g.PreShutdown()
+	}
+	result := make(chan error, 1)
+	go func() {
+		result <- g.PreShutdown()
+	}()
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(period):
+		return errors.Errorf("pre-shutdown of '%T' exceeded grace period %v", g, period)
+	}
+}
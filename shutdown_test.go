@@ -0,0 +1,218 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+//go:build unix
+
+package context_test
+
+import (
+	stdcontext "context"
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestShutdownHook(t *testing.T) {
+
+	context.Verbose = false
+	resource := &parentResource{}
+
+	ctx, err := context.Create(resource)
+	require.Nil(t, err)
+
+	done := context.ShutdownHook(ctx)
+	require.Nil(t, syscall.Kill(os.Getpid(), syscall.SIGTERM))
+
+	select {
+	case <-done:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("shutdown is not completed")
+	}
+	require.True(t, resource.destroyed)
+	require.Equal(t, context.PhaseClosed, ctx.Phase())
+
+}
+
+func TestShutdownHookClosed(t *testing.T) {
+
+	context.Verbose = false
+	resource := &parentResource{}
+
+	ctx, err := context.Create(resource)
+	require.Nil(t, err)
+
+	done := context.ShutdownHook(ctx)
+	require.Nil(t, ctx.Close())
+
+	select {
+	case <-done:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("shutdown hook is not stopped by close")
+	}
+	require.True(t, resource.destroyed)
+
+}
+
+func TestShutdownHookWithTimeout(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create(&slowCloser[int]{delay: 2 * time.Second})
+	require.Nil(t, err)
+
+	done := context.ShutdownHookWithTimeout(ctx, 10*time.Millisecond)
+	require.Nil(t, syscall.Kill(os.Getpid(), syscall.SIGINT))
+
+	select {
+	case <-done:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("shutdown is not completed")
+	}
+
+}
+
+type gracefulWorker struct {
+	preShutdown bool
+	destroyed   bool
+	order       []string
+}
+
+func (t *gracefulWorker) GracePeriod() time.Duration {
+	return 100 * time.Millisecond
+}
+
+func (t *gracefulWorker) PreShutdown() error {
+	t.preShutdown = true
+	t.order = append(t.order, "PreShutdown")
+	return nil
+}
+
+func (t *gracefulWorker) Destroy() error {
+	t.destroyed = true
+	t.order = append(t.order, "Destroy")
+	return nil
+}
+
+type stuckWorker struct{}
+
+func (t *stuckWorker) GracePeriod() time.Duration {
+	return 10 * time.Millisecond
+}
+
+func (t *stuckWorker) PreShutdown() error {
+	time.Sleep(time.Second)
+	return nil
+}
+
+func TestShutdown(t *testing.T) {
+
+	context.Verbose = false
+	worker := &gracefulWorker{}
+
+	ctx, err := context.Create(worker)
+	require.Nil(t, err)
+
+	done := context.Shutdown(ctx, syscall.SIGUSR1)
+	require.Nil(t, syscall.Kill(os.Getpid(), syscall.SIGUSR1))
+
+	select {
+	case err := <-done:
+		require.Nil(t, err)
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("shutdown is not completed")
+	}
+	require.True(t, worker.preShutdown)
+	require.True(t, worker.destroyed)
+	require.Equal(t, []string{"PreShutdown", "Destroy"}, worker.order)
+	require.Equal(t, context.PhaseClosed, ctx.Phase())
+
+}
+
+func TestShutdownGracePeriod(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create(&stuckWorker{})
+	require.Nil(t, err)
+
+	done := context.Shutdown(ctx, syscall.SIGUSR2)
+	require.Nil(t, syscall.Kill(os.Getpid(), syscall.SIGUSR2))
+
+	select {
+	case err := <-done:
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "exceeded grace period")
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("shutdown is not completed")
+	}
+	require.Equal(t, context.PhaseClosed, ctx.Phase())
+
+}
+
+func TestShutdownClosed(t *testing.T) {
+
+	context.Verbose = false
+	worker := &gracefulWorker{}
+
+	ctx, err := context.Create(worker)
+	require.Nil(t, err)
+
+	done := context.Shutdown(ctx, syscall.SIGUSR1)
+	require.Nil(t, ctx.Close())
+
+	select {
+	case err := <-done:
+		require.Equal(t, stdcontext.Canceled, err)
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("shutdown is not stopped by close")
+	}
+	require.False(t, worker.preShutdown)
+	require.True(t, worker.destroyed)
+
+}
+
+func TestGracefulClose(t *testing.T) {
+
+	context.Verbose = false
+	resource := &parentResource{}
+
+	ctx, err := context.Create(resource)
+	require.Nil(t, err)
+
+	signals := make(chan os.Signal, 1)
+	signals <- syscall.SIGTERM
+	require.Nil(t, ctx.GracefulClose(signals, time.Second))
+	require.True(t, resource.destroyed)
+	require.Equal(t, context.PhaseClosed, ctx.Phase())
+
+	ctx, err = context.Create(&slowCloser[int]{delay: 2 * time.Second})
+	require.Nil(t, err)
+
+	signals <- syscall.SIGTERM
+	err = ctx.GracefulClose(signals, 10*time.Millisecond)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "close interrupted")
+
+}
@@ -0,0 +1,160 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	stdcontext "context"
+	"github.com/pkg/errors"
+	"reflect"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Copy of the registry state taken by Snapshot()
+ */
+
+type ContextSnapshot struct {
+	ctx         *context
+	beansByName map[string][]*bean
+	beansByType map[reflect.Type]*bean
+	lazyTypes   map[reflect.Type]bool
+}
+
+func (t *context) Snapshot() ContextSnapshot {
+	t.registry.RLock()
+	defer t.registry.RUnlock()
+	s := ContextSnapshot{
+		ctx:         t,
+		beansByName: make(map[string][]*bean, len(t.registry.beansByName)),
+		beansByType: make(map[reflect.Type]*bean, len(t.registry.beansByType)),
+		lazyTypes:   make(map[reflect.Type]bool, len(t.registry.lazyTypes)),
+	}
+	for name, list := range t.registry.beansByName {
+		s.beansByName[name] = append([]*bean(nil), list...)
+	}
+	for typ, b := range t.registry.beansByType {
+		s.beansByType[typ] = b
+	}
+	for typ := range t.registry.lazyTypes {
+		s.lazyTypes[typ] = true
+	}
+	return s
+}
+
+/**
+	Restore the registry state from the snapshot.
+	Beans registered since the snapshot are destroyed unless they are in core,
+	beans unregistered since the snapshot are initialized again unless they are in core, because such beans were not destroyed.
+ */
+func (t *context) Restore(s ContextSnapshot) error {
+	if t.IsFrozen() {
+		return ErrContextFrozen
+	}
+	if s.ctx != t {
+		return errors.New("snapshot was taken from another context")
+	}
+	t.registry.Lock()
+	current := beanSet(t.registry.beansByType)
+	t.registry.beansByName = make(map[string][]*bean, len(s.beansByName))
+	for name, list := range s.beansByName {
+		t.registry.beansByName[name] = append([]*bean(nil), list...)
+	}
+	t.registry.beansByType = make(map[reflect.Type]*bean, len(s.beansByType))
+	for typ, b := range s.beansByType {
+		t.registry.beansByType[typ] = b
+	}
+	t.registry.lazyTypes = make(map[reflect.Type]bool, len(s.lazyTypes))
+	for typ := range s.lazyTypes {
+		t.registry.lazyTypes[typ] = true
+	}
+	t.registry.Unlock()
+
+	restored := beanSet(s.beansByType)
+	var err []error
+	for b := range current {
+		if d, ok := b.obj.(DisposableBean); ok && !restored[b] && !t.isCoreObject(b.obj) {
+			if e := t.destroyBean(d); e != nil {
+				err = append(err, e)
+			}
+		}
+	}
+	for b := range restored {
+		if !current[b] && !t.isCoreObject(b.obj) {
+			if _, e := t.initBean(stdcontext.Background(), b.obj); e != nil {
+				err = append(err, e)
+			}
+		}
+	}
+	return multiple(err)
+}
+
+/**
+	Check if the object is the instance of a bean in core, such beans are destroyed only by Close()
+ */
+func (t *context) isCoreObject(obj interface{}) bool {
+	for _, b := range t.coreBeans() {
+		if b.obj == obj {
+			return true
+		}
+	}
+	return false
+}
+
+func beanSet(beans map[reflect.Type]*bean) map[*bean]bool {
+	set := make(map[*bean]bool, len(beans))
+	for _, b := range beans {
+		set[b] = true
+	}
+	return set
+}
+
+/**
+	Register obj under the type instead of the current bean.
+	The mock is returned by Bean(), Lookup() and injected on runtime, injections made on creation of context are not changed.
+
+	Example:
+		snap := ctx.Snapshot()
+		ctx.Mock(StorageClass, &mockStorage{})
+		runTest()
+		ctx.Restore(snap)
+ */
+func (t *context) Mock(typ reflect.Type, obj interface{}) error {
+	if t.IsFrozen() {
+		return ErrContextFrozen
+	}
+	if obj == nil {
+		return errors.Errorf("null mock is not allowed for '%v'", typ)
+	}
+	classPtr := reflect.TypeOf(obj)
+	if !classPtr.AssignableTo(typ) {
+		return errors.Errorf("mock of type '%v' does not implement '%v'", classPtr, typ)
+	}
+	mock := &bean{
+		obj:      obj,
+		exposed:  obj,
+		valuePtr: reflect.ValueOf(obj),
+		beanDef:  &beanDef{classPtr: classPtr},
+	}
+	old, _ := t.getBean(typ)
+	t.registry.replaceBean(typ, old, mock)
+	return nil
+}
@@ -0,0 +1,115 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"log"
+	"os"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type mockStorage struct {
+	configStorage
+	destroyed bool
+}
+
+func (t *mockStorage) Destroy() error {
+	t.destroyed = true
+	return nil
+}
+
+func TestSnapshotRestore(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+	storage := &storageImpl{}
+
+	ctx, err := context.Create(logger, storage, context.Interface((*Storage)(nil)))
+	require.Nil(t, err)
+
+	snap := ctx.Snapshot()
+
+	mock := &mockStorage{}
+	require.Nil(t, ctx.Mock(StorageClass, mock))
+	require.Equal(t, mock, ctx.MustBean(StorageClass))
+	require.Equal(t, mock, ctx.Lookup("context_test.Storage")[0])
+
+	controller := &struct{ Storage `inject` }{}
+	_, err = ctx.Inject(controller)
+	require.Nil(t, err)
+	controller.Store("key", "value")
+	require.Equal(t, "value", mock.Load("key"))
+
+	require.Nil(t, ctx.Restore(snap))
+	require.Equal(t, storage, ctx.MustBean(StorageClass))
+	require.Equal(t, 1, len(ctx.Lookup("context_test.Storage")))
+	require.True(t, mock.destroyed)
+
+	require.NotNil(t, ctx.Mock(StorageClass, logger))
+
+	other, err := context.Create()
+	require.Nil(t, err)
+	require.NotNil(t, other.Restore(snap))
+
+}
+
+type lifecycleStorage struct {
+	configStorage
+	initialized int
+	destroyed   int
+}
+
+func (t *lifecycleStorage) PostConstruct() error {
+	t.initialized++
+	return nil
+}
+
+func (t *lifecycleStorage) Destroy() error {
+	t.destroyed++
+	return nil
+}
+
+func TestRestoreCoreBean(t *testing.T) {
+
+	context.Verbose = false
+	storage := &lifecycleStorage{}
+
+	ctx, err := context.Create(storage)
+	require.Nil(t, err)
+	require.Equal(t, 1, storage.initialized)
+	require.Equal(t, storage, ctx.MustBean(StorageClass))
+
+	snap := ctx.Snapshot()
+	require.Nil(t, ctx.Mock(StorageClass, &mockStorage{}))
+	require.Nil(t, ctx.Restore(snap))
+
+	require.Equal(t, storage, ctx.MustBean(StorageClass))
+	require.Equal(t, 1, storage.initialized)
+	require.Equal(t, 0, storage.destroyed)
+
+	require.Nil(t, ctx.Close())
+	require.Equal(t, 1, storage.destroyed)
+
+}
@@ -0,0 +1,188 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"reflect"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Lifecycle phase of the context
+ */
+
+type Phase int32
+
+const (
+	PhaseCreating Phase = iota
+	PhaseReady
+	PhaseFailed
+	PhaseClosing
+	PhaseClosed
+)
+
+func (p Phase) String() string {
+	switch p {
+	case PhaseCreating:
+		return "creating"
+	case PhaseReady:
+		return "ready"
+	case PhaseFailed:
+		return "failed"
+	case PhaseClosing:
+		return "closing"
+	case PhaseClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+/**
+	Statistics of the context
+ */
+
+type Stats struct {
+
+	/**
+		Current lifecycle phase
+	 */
+	Phase          Phase
+
+	/**
+		Number of beans in core
+	 */
+	Beans          int
+
+	/**
+		Number of registered type-to-implementation mappings
+	 */
+	Bindings       int
+
+	/**
+		Time when creation of context was completed
+	 */
+	CreatedAt      time.Time
+
+	/**
+		Total time of the creation, including scan, injection and initialization
+	 */
+	CreateDuration time.Duration
+
+	/**
+		Time spent in PostConstruct() calls
+	 */
+	InitDuration   time.Duration
+
+	/**
+		Number of Inject() calls that found the class in the runtime cache, see Warmup()
+	 */
+	CacheHits      int64
+
+	/**
+		Number of Inject() calls that investigated the class by reflection
+	 */
+	CacheMisses    int64
+}
+
+/**
+	Dependency graph of beans in core
+ */
+
+type Graph struct {
+	Nodes []GraphNode
+}
+
+type GraphNode struct {
+
+	/**
+		Class of the bean
+	 */
+	Type         reflect.Type
+
+	/**
+		Classes of the beans injected in to this bean
+	 */
+	Dependencies []reflect.Type
+}
+
+func (t *context) Phase() Phase {
+	return Phase(atomic.LoadInt32(&t.phase))
+}
+
+func (t *context) setPhase(phase Phase) {
+	atomic.StoreInt32(&t.phase, int32(phase))
+}
+
+func (t *context) Stats() Stats {
+	t.registry.RLock()
+	bindings := len(t.registry.beansByType)
+	t.registry.RUnlock()
+	return Stats{
+		Phase:          t.Phase(),
+		Beans:          len(t.coreBeans()),
+		Bindings:       bindings,
+		CreatedAt:      t.createdAt,
+		CreateDuration: t.createDuration,
+		InitDuration:   t.initDuration,
+		CacheHits:      atomic.LoadInt64(&t.cacheHits),
+		CacheMisses:    atomic.LoadInt64(&t.cacheMisses),
+	}
+}
+
+func (t *context) Graph() Graph {
+	var graph Graph
+	for classPtr, b := range t.coreBeans() {
+		node := GraphNode{Type: classPtr}
+		for _, d := range b.dependencies {
+			node.Dependencies = append(node.Dependencies, d.beanDef.classPtr)
+		}
+		sortTypes(node.Dependencies)
+		graph.Nodes = append(graph.Nodes, node)
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool {
+		return graph.Nodes[i].Type.String() < graph.Nodes[j].Type.String()
+	})
+	return graph
+}
+
+func (t *context) InjectionGraph() map[reflect.Type][]reflect.Type {
+	core := t.coreBeans()
+	graph := make(map[reflect.Type][]reflect.Type, len(core))
+	for classPtr, b := range core {
+		var list []reflect.Type
+		for _, f := range b.beanDef.fields {
+			list = append(list, f.fieldType)
+		}
+		graph[classPtr] = list
+	}
+	return graph
+}
+
+func sortTypes(list []reflect.Type) {
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].String() < list[j].String()
+	})
+}
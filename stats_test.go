@@ -0,0 +1,85 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"log"
+	"os"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestStatsAndGraph(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+
+	ctx, err := context.Create(
+		logger,
+		&storageImpl{},
+		&configServiceImpl{},
+		&userServiceImpl{},
+	)
+	require.Nil(t, err)
+	require.Equal(t, context.PhaseReady, ctx.Phase())
+
+	stats := ctx.Stats()
+	require.Equal(t, 4, stats.Beans)
+	require.Equal(t, 3, stats.Bindings)
+	require.False(t, stats.CreatedAt.IsZero())
+
+	graph := ctx.Graph()
+	require.Equal(t, 4, len(graph.Nodes))
+	for _, node := range graph.Nodes {
+		if node.Type == reflect.TypeOf(&userServiceImpl{}) {
+			require.Equal(t, []reflect.Type{reflect.TypeOf(&configServiceImpl{}), reflect.TypeOf(&storageImpl{})}, node.Dependencies)
+		}
+	}
+
+	require.Nil(t, ctx.Close())
+	require.Equal(t, context.PhaseClosed, ctx.Phase())
+
+}
+
+func TestInjectionGraph(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+
+	ctx, err := context.Create(
+		logger,
+		&storageImpl{},
+		&configServiceImpl{},
+		&userServiceImpl{},
+	)
+	require.Nil(t, err)
+
+	graph := ctx.InjectionGraph()
+	require.Equal(t, 4, len(graph))
+	require.Equal(t, []reflect.Type{StorageClass, ConfigServiceClass}, graph[reflect.TypeOf(&userServiceImpl{})])
+	require.Equal(t, []reflect.Type{StorageClass}, graph[reflect.TypeOf(&configServiceImpl{})])
+	require.Empty(t, graph[reflect.TypeOf(logger)])
+
+}
@@ -0,0 +1,106 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	stdcontext "context"
+	"reflect"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Derive the context from the standard context of the caller.
+	PostConstruct(ctx) of ContextInitializingBean receives goCtx, Deadline() and Value() of the context fall back to goCtx,
+	the context is closed when goCtx is done and Wait() returns the error of goCtx.
+
+	Example:
+		goCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		ctx, err := context.CreateWithOptions(beans, context.WithBaseContext(goCtx))
+ */
+func WithBaseContext(goCtx stdcontext.Context) Option {
+	return func(conf *contextConfig) {
+		conf.baseContext = goCtx
+	}
+}
+
+func (t *contextConfig) background() stdcontext.Context {
+	if t.baseContext != nil {
+		return t.baseContext
+	}
+	return stdcontext.Background()
+}
+
+/**
+	Close the context when the base context is done
+ */
+func (t *context) watchBaseContext(goCtx stdcontext.Context) {
+	select {
+	case <-goCtx.Done():
+		t.cancel(goCtx.Err())
+	case <-t.done:
+	}
+}
+
+/**
+	Implementation of the standard context.Context interface
+ */
+
+func (t *context) Deadline() (deadline time.Time, ok bool) {
+	if t.conf.baseContext != nil {
+		return t.conf.baseContext.Deadline()
+	}
+	return
+}
+
+func (t *context) Done() <-chan struct{} {
+	return t.done
+}
+
+/**
+	Returns context.Canceled after Done() is closed, nil before
+ */
+func (t *context) Err() error {
+	select {
+	case <-t.done:
+		return stdcontext.Canceled
+	default:
+		return nil
+	}
+}
+
+/**
+	Returns the bean if key is reflect.Type, otherwise the value of the base context
+ */
+func (t *context) Value(key interface{}) interface{} {
+	if typ, ok := key.(reflect.Type); ok {
+		if b, ok := t.getBean(typ); ok {
+			return b.exposed
+		}
+		return nil
+	}
+	if t.conf.baseContext != nil {
+		return t.conf.baseContext.Value(key)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	stdcontext "context"
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+var memoryStorageClass = reflect.TypeOf((*memoryStorage)(nil))
+
+/**
+	Mock of the database driver that takes per-request beans from the context
+ */
+func queryContext(ctx stdcontext.Context, key string) (string, bool) {
+	if storage, ok := ctx.Value(StorageClass).(Storage); ok {
+		return storage.Load(key), true
+	}
+	return "", false
+}
+
+func TestStandardContext(t *testing.T) {
+
+	context.Verbose = false
+	storage := &memoryStorage{internal: map[string]string{"user:alex": "admin"}}
+
+	ctx, err := context.Create(storage, context.Interface((*Storage)(nil)))
+	require.Nil(t, err)
+
+	value, ok := queryContext(ctx, "user:alex")
+	require.True(t, ok)
+	require.Equal(t, "admin", value)
+
+	require.True(t, storage == ctx.Value(memoryStorageClass))
+	require.Nil(t, ctx.Value("unknown"))
+	require.Nil(t, ctx.Value(UserServiceClass))
+
+	_, hasDeadline := ctx.Deadline()
+	require.False(t, hasDeadline)
+	require.Nil(t, ctx.Err())
+
+	select {
+	case <-ctx.Done():
+		t.Fatal("context is done before Close()")
+	default:
+	}
+
+	child, cancel := stdcontext.WithCancel(ctx)
+	defer cancel()
+
+	require.Nil(t, ctx.Close())
+	<-ctx.Done()
+	<-child.Done()
+	require.Equal(t, stdcontext.Canceled, ctx.Err())
+
+}
+
+type baseContextKey struct{}
+
+func TestBaseContext(t *testing.T) {
+
+	context.Verbose = false
+
+	deadline := time.Now().Add(time.Hour)
+	base, cancel := stdcontext.WithDeadline(stdcontext.WithValue(stdcontext.Background(), baseContextKey{}, "request"), deadline)
+	defer cancel()
+
+	ctx, err := context.CreateWithOptions([]interface{}{&memoryStorage{}}, context.WithBaseContext(base))
+	require.Nil(t, err)
+
+	require.Equal(t, "request", ctx.Value(baseContextKey{}))
+	require.NotNil(t, ctx.Value(memoryStorageClass))
+	d, ok := ctx.Deadline()
+	require.True(t, ok)
+	require.Equal(t, deadline, d)
+	require.Nil(t, ctx.Err())
+
+	cancel()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("context is not done after cancel of the base context")
+	}
+	require.Equal(t, stdcontext.Canceled, ctx.Err())
+	require.Equal(t, stdcontext.Canceled, ctx.Wait())
+
+}
@@ -0,0 +1,106 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	stdcontext "context"
+	"github.com/pkg/errors"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Run creation of context in a separate goroutine and stop waiting for it on timeout.
+	Beans implementing ContextInitializingBean receive the deadline in PostConstruct(ctx).
+
+	On timeout the creation goroutine stops initialization after the running PostConstruct() returns
+	and destroys already initialized beans. If creation completes after the timeout, the context is closed.
+ */
+func createWithTimeout(scan []interface{}, conf *contextConfig) (Context, error) {
+
+	goCtx, cancel := stdcontext.WithTimeout(conf.background(), conf.initTimeout)
+	defer cancel()
+
+	type result struct {
+		ctx *context
+		err error
+	}
+
+	done := make(chan result)
+	abandoned := make(chan struct{})
+
+	go func() {
+		ctx, err := create(scan, conf, goCtx)
+		select {
+		case done <- result{ctx, err}:
+		case <-abandoned:
+			if err == nil {
+				ctx.Close()
+			}
+		}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil && goCtx.Err() != nil {
+			return nil, errors.Wrapf(goCtx.Err(), "creation of context exceeded %v", conf.initTimeout)
+		}
+		return toContext(r.ctx, r.err)
+	case <-goCtx.Done():
+		close(abandoned)
+		return nil, errors.Wrapf(goCtx.Err(), "creation of context exceeded %v", conf.initTimeout)
+	}
+}
+
+/**
+	Limit duration of each PostConstruct() call, Create() fails with error naming the slow bean.
+	Go can not stop the goroutine, so PostConstruct() continues in background after the timeout.
+	Beans implementing ContextInitializingBean receive the context cancelled on timeout and could stop.
+ */
+func WithPostConstructTimeout(d time.Duration) Option {
+	return func(conf *contextConfig) {
+		conf.postConstructTimeout = d
+	}
+}
+
+func (t *context) withPostConstructTimeout(ctx stdcontext.Context, obj interface{}, fn func(ctx stdcontext.Context) error) error {
+	d := t.conf.postConstructTimeout
+	if d <= 0 {
+		return fn(ctx)
+	}
+	goCtx, cancel := stdcontext.WithTimeout(ctx, d)
+	defer cancel()
+	var err error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		err = fn(goCtx)
+	}()
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return err
+	case <-timer.C:
+		return errors.Errorf("%s of '%T' exceeded %v", LifecyclePostConstruct, obj, d)
+	}
+}
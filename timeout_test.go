@@ -0,0 +1,146 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	stdcontext "context"
+	"errors"
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type slowInitBean struct {
+	delay time.Duration
+}
+
+func (t *slowInitBean) PostConstruct() error {
+	time.Sleep(t.delay)
+	return nil
+}
+
+type cancellableInitBean struct {
+	cancelled int32
+}
+
+func (t *cancellableInitBean) PostConstruct(ctx stdcontext.Context) error {
+	select {
+	case <-ctx.Done():
+		atomic.StoreInt32(&t.cancelled, 1)
+		return ctx.Err()
+	case <-time.After(time.Second):
+		return nil
+	}
+}
+
+type disposableInitBean struct {
+	destroyed int32
+}
+
+func (t *disposableInitBean) Destroy() error {
+	atomic.AddInt32(&t.destroyed, 1)
+	return nil
+}
+
+func TestInitTimeout(t *testing.T) {
+
+	context.Verbose = false
+
+	initialized := &disposableInitBean{}
+	start := time.Now()
+	ctx, err := context.CreateWithOptions([]interface{}{
+		initialized,
+		&slowInitBean{delay: 300 * time.Millisecond},
+	}, context.WithInitTimeout(10*time.Millisecond))
+	require.NotNil(t, err)
+	require.Nil(t, ctx)
+	require.True(t, errors.Is(err, stdcontext.DeadlineExceeded))
+	require.True(t, time.Since(start) < 100*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&initialized.destroyed) == 1
+	}, time.Second, time.Millisecond)
+
+}
+
+func TestInitTimeoutCancelsPostConstruct(t *testing.T) {
+
+	context.Verbose = false
+
+	bean := &cancellableInitBean{}
+	_, err := context.CreateWithOptions([]interface{}{bean}, context.WithInitTimeout(10*time.Millisecond))
+	require.True(t, errors.Is(err, stdcontext.DeadlineExceeded))
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&bean.cancelled) == 1
+	}, time.Second, time.Millisecond)
+
+}
+
+func TestInitTimeoutNotExceeded(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.CreateWithOptions([]interface{}{
+		&slowInitBean{delay: time.Millisecond},
+	}, context.WithInitTimeout(time.Second))
+	require.Nil(t, err)
+	require.Equal(t, context.PhaseReady, ctx.Phase())
+
+}
+
+func TestPostConstructTimeout(t *testing.T) {
+
+	context.Verbose = false
+
+	_, err := context.CreateWithOptions([]interface{}{
+		&slowInitBean{delay: 100 * time.Millisecond},
+	}, context.WithPostConstructTimeout(10 * time.Millisecond))
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "slowInitBean")
+	require.Contains(t, err.Error(), "exceeded")
+
+	ctx, err := context.CreateWithOptions([]interface{}{
+		&slowInitBean{delay: time.Millisecond},
+	}, context.WithPostConstructTimeout(time.Second))
+	require.Nil(t, err)
+	require.Nil(t, ctx.Close())
+
+}
+
+func TestPostConstructTimeoutCancelsPostConstruct(t *testing.T) {
+
+	context.Verbose = false
+
+	bean := &cancellableInitBean{}
+	_, err := context.CreateWithOptions([]interface{}{bean}, context.WithPostConstructTimeout(10*time.Millisecond))
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "exceeded")
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&bean.cancelled) == 1
+	}, 500*time.Millisecond, time.Millisecond)
+
+}
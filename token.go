@@ -0,0 +1,100 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"reflect"
+	"sync"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Cache of resolved types, key is TypeToken[T], value is reflect.Type
+ */
+var tokenCache sync.Map
+
+/**
+	Compile-time handle of the type T.
+	Resolves reflect.Type only once per type and caches it for the lifetime of the process.
+
+	Example:
+		var UserServiceToken = context.TokenOf[app.UserService]()
+ */
+
+type TypeToken[T any] struct{}
+
+func TokenOf[T any]() TypeToken[T] {
+	return TypeToken[T]{}
+}
+
+/**
+	Gets cached reflect.Type of T
+ */
+func (t TypeToken[T]) Type() reflect.Type {
+	if typ, ok := tokenCache.Load(t); ok {
+		return typ.(reflect.Type)
+	}
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	tokenCache.Store(t, typ)
+	return typ
+}
+
+/**
+	Bean wrapper that carries the pointer type of the instance, so Create() does not need to resolve it by reflection.
+
+	Example:
+		ctx, err := context.Create(
+			context.NewRegistered(&storageImpl{}),
+			context.NewRegistered(&userServiceImpl{}),
+		)
+ */
+
+type Registered[T any] struct {
+	obj *T
+}
+
+func NewRegistered[T any](obj *T) Registered[T] {
+	return Registered[T]{obj: obj}
+}
+
+func (t Registered[T]) Object() *T {
+	return t.obj
+}
+
+func (t Registered[T]) registeredObject() interface{} {
+	if t.obj == nil {
+		return nil
+	}
+	return t.obj
+}
+
+func (t Registered[T]) registeredType() reflect.Type {
+	return TokenOf[*T]().Type()
+}
+
+/**
+	Common interface for all Registered[T] instances, used by Create() to unwrap them
+ */
+type registeredBean interface {
+	registeredObject() interface{}
+	registeredType() reflect.Type
+}
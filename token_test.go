@@ -0,0 +1,120 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"log"
+	"os"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func TestTokenOf(t *testing.T) {
+
+	require.Equal(t, StorageClass, context.TokenOf[Storage]().Type())
+	require.Equal(t, StorageClass, context.TokenOf[Storage]().Type())
+	require.Equal(t, UserServiceClass, context.TokenOf[UserService]().Type())
+
+}
+
+func TestCreateRegistered(t *testing.T) {
+
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+
+	storage := &storageImpl{}
+	var ctx, err = context.Create(
+		context.NewRegistered(logger),
+		context.NewRegistered(storage),
+		&configServiceImpl{},
+		context.NewRegistered(&userServiceImpl{}),
+	)
+	require.Nil(t, err)
+	require.Equal(t, 4, len(ctx.Core()))
+	require.Equal(t, storage, ctx.MustBean(StorageClass))
+	require.Equal(t, logger, storage.Logger)
+
+	_, err = context.Create(context.NewRegistered[storageImpl](nil))
+	require.NotNil(t, err)
+
+}
+
+type benchBean[A, B any] struct{}
+
+func benchRow[A any](registered bool) []interface{} {
+	if registered {
+		return []interface{}{
+			context.NewRegistered(&benchBean[A, [0]int]{}), context.NewRegistered(&benchBean[A, [1]int]{}),
+			context.NewRegistered(&benchBean[A, [2]int]{}), context.NewRegistered(&benchBean[A, [3]int]{}),
+			context.NewRegistered(&benchBean[A, [4]int]{}), context.NewRegistered(&benchBean[A, [5]int]{}),
+			context.NewRegistered(&benchBean[A, [6]int]{}), context.NewRegistered(&benchBean[A, [7]int]{}),
+			context.NewRegistered(&benchBean[A, [8]int]{}), context.NewRegistered(&benchBean[A, [9]int]{}),
+		}
+	}
+	return []interface{}{
+		&benchBean[A, [0]int]{}, &benchBean[A, [1]int]{}, &benchBean[A, [2]int]{}, &benchBean[A, [3]int]{},
+		&benchBean[A, [4]int]{}, &benchBean[A, [5]int]{}, &benchBean[A, [6]int]{}, &benchBean[A, [7]int]{},
+		&benchBean[A, [8]int]{}, &benchBean[A, [9]int]{},
+	}
+}
+
+/**
+	Produces 100 beans of distinct types
+ */
+func benchBeans(registered bool) []interface{} {
+	var beans []interface{}
+	beans = append(beans, benchRow[[0]byte](registered)...)
+	beans = append(beans, benchRow[[1]byte](registered)...)
+	beans = append(beans, benchRow[[2]byte](registered)...)
+	beans = append(beans, benchRow[[3]byte](registered)...)
+	beans = append(beans, benchRow[[4]byte](registered)...)
+	beans = append(beans, benchRow[[5]byte](registered)...)
+	beans = append(beans, benchRow[[6]byte](registered)...)
+	beans = append(beans, benchRow[[7]byte](registered)...)
+	beans = append(beans, benchRow[[8]byte](registered)...)
+	beans = append(beans, benchRow[[9]byte](registered)...)
+	return beans
+}
+
+func BenchmarkCreateReflect(b *testing.B) {
+	context.Verbose = false
+	beans := benchBeans(false)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := context.Create(beans...); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCreateToken(b *testing.B) {
+	context.Verbose = false
+	beans := benchBeans(true)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := context.Create(beans...); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
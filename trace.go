@@ -0,0 +1,109 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"reflect"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Phase of initialization trace for injection of the bean dependencies
+ */
+const TraceInject = "Inject"
+
+/**
+	Timing of single step of Create() for the bean
+ */
+type TraceEntry struct {
+	/**
+		Class of the pointer to the struct of the bean
+	 */
+	BeanType reflect.Type
+	/**
+		TraceInject or LifecyclePostConstruct
+	 */
+	Phase    string
+	Duration time.Duration
+	Error    error
+}
+
+/**
+	Initialization trace of the context in the order of steps
+ */
+type Trace []TraceEntry
+
+/**
+	Sum of durations of all entries
+ */
+func (t Trace) TotalDuration() time.Duration {
+	var total time.Duration
+	for _, e := range t {
+		total += e.Duration
+	}
+	return total
+}
+
+/**
+	Entries with duration greater or equal to threshold
+ */
+func (t Trace) SlowBeans(threshold time.Duration) []TraceEntry {
+	var list []TraceEntry
+	for _, e := range t {
+		if e.Duration >= threshold {
+			list = append(list, e)
+		}
+	}
+	return list
+}
+
+func (t *context) Trace() Trace {
+	list := make(Trace, len(t.trace))
+	copy(list, t.trace)
+	return list
+}
+
+func (t *context) record(classPtr reflect.Type, phase string, start time.Time, err error) {
+	t.trace = append(t.trace, TraceEntry{
+		BeanType: classPtr,
+		Phase:    phase,
+		Duration: time.Since(start),
+		Error:    err,
+	})
+}
+
+/**
+	Inject the bean, record the timing in trace and metrics and notify observers
+ */
+func (t *context) injectTraced(inject *injection, service *bean) error {
+	start := time.Now()
+	err := inject.inject(service, t.conf.unexportedFields)
+	t.record(inject.owner.beanDef.classPtr, TraceInject, start, err)
+	if err == nil {
+		t.conf.metrics.RecordInjection(inject.owner.beanDef.classPtr, service.beanDef.classPtr, time.Since(start))
+		t.notify(func(o ContextObserver) {
+			o.OnBeanInjected(inject.owner.beanDef.classPtr, service.beanDef.classPtr, inject.injectionDef.fieldName)
+		})
+	}
+	return err
+}
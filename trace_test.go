@@ -0,0 +1,75 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type slowDependency struct{}
+
+type slowStartingBean struct {
+	Dependency *slowDependency `inject`
+}
+
+func (t *slowStartingBean) PostConstruct() error {
+	time.Sleep(5 * time.Millisecond)
+	return nil
+}
+
+var SlowStartingBeanClass = reflect.TypeOf((*slowStartingBean)(nil))
+
+func TestTrace(t *testing.T) {
+
+	ctx, err := context.Create(
+		&slowDependency{},
+		&slowStartingBean{},
+	)
+	require.Nil(t, err)
+	defer ctx.Close()
+
+	trace := ctx.Trace()
+	require.Equal(t, 2, len(trace))
+
+	require.Equal(t, SlowStartingBeanClass, trace[0].BeanType)
+	require.Equal(t, context.TraceInject, trace[0].Phase)
+	require.Nil(t, trace[0].Error)
+
+	require.Equal(t, SlowStartingBeanClass, trace[1].BeanType)
+	require.Equal(t, context.LifecyclePostConstruct, trace[1].Phase)
+	require.True(t, trace[1].Duration >= 5*time.Millisecond)
+
+	require.True(t, trace.TotalDuration() >= 5*time.Millisecond)
+
+	slow := trace.SlowBeans(5 * time.Millisecond)
+	require.Equal(t, 1, len(slow))
+	require.Equal(t, SlowStartingBeanClass, slow[0].BeanType)
+
+	trace[0].Phase = "changed"
+	require.Equal(t, context.TraceInject, ctx.Trace()[0].Phase)
+
+}
@@ -0,0 +1,88 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"github.com/pkg/errors"
+	"reflect"
+	"sort"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Registry of types by names to create context from external configuration.
+
+	Example:
+		registry := context.NewTypeRegistry()
+		registry.RegisterType("StorageImpl", reflect.TypeOf(storageImpl{}))
+		registry.RegisterType("UserServiceImpl", reflect.TypeOf(userServiceImpl{}))
+
+		ctx, err := context.CreateFromConfig(map[string]string{
+			"storage": "StorageImpl",
+			"users":   "UserServiceImpl",
+		}, registry)
+ */
+
+type TypeRegistry map[string]reflect.Type
+
+func NewTypeRegistry() TypeRegistry {
+	return make(TypeRegistry)
+}
+
+func (t TypeRegistry) RegisterType(name string, typ reflect.Type) {
+	t[name] = typ
+}
+
+func (t TypeRegistry) LookupType(name string) (reflect.Type, bool) {
+	typ, ok := t[name]
+	return typ, ok
+}
+
+/**
+	Create context from configuration, where key is the name of the bean and value is the name of the type in registry.
+	Only concrete struct types are instantiated, interfaces are resolved by injection.
+	Beans are created in order of their names in configuration and registered under these names,
+	so they could be found by Lookup(name) and injected in to fields with tag `inject:"name"`, like in NewContextFromMap().
+ */
+func CreateFromConfig(cfg map[string]string, registry TypeRegistry) (Context, error) {
+	var names []string
+	for name := range cfg {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var scan []interface{}
+	for _, name := range names {
+		typeName := cfg[name]
+		typ, ok := registry.LookupType(typeName)
+		if !ok {
+			return nil, errors.Errorf("type '%s' of bean '%s' is not registered", typeName, name)
+		}
+		if typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct {
+			return nil, errors.Errorf("type '%v' of bean '%s' is not a struct", typ, name)
+		}
+		scan = append(scan, namedBean{name: name, obj: reflect.New(typ).Interface()})
+	}
+	return Create(scan...)
+}
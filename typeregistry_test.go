@@ -0,0 +1,109 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type configStorage struct {
+	data map[string]string
+}
+
+func (t *configStorage) Load(key string) string {
+	return t.data[key]
+}
+
+func (t *configStorage) Store(key, value string) {
+	if t.data == nil {
+		t.data = make(map[string]string)
+	}
+	t.data[key] = value
+}
+
+func TestCreateFromConfig(t *testing.T) {
+
+	context.Verbose = false
+
+	registry := context.NewTypeRegistry()
+	registry.RegisterType("StorageImpl", reflect.TypeOf(configStorage{}))
+	registry.RegisterType("ConfigServiceImpl", reflect.TypeOf((*configServiceImpl)(nil)))
+	registry.RegisterType("UserServiceImpl", reflect.TypeOf(userServiceImpl{}))
+
+	typ, ok := registry.LookupType("StorageImpl")
+	require.True(t, ok)
+	require.Equal(t, reflect.TypeOf(configStorage{}), typ)
+
+	// beans: [StorageImpl, ConfigServiceImpl, UserServiceImpl]
+	ctx, err := context.CreateFromConfig(map[string]string{
+		"storage": "StorageImpl",
+		"config":  "ConfigServiceImpl",
+		"users":   "UserServiceImpl",
+	}, registry)
+	require.Nil(t, err)
+
+	expected, err := context.Create(&configStorage{}, &configServiceImpl{}, &userServiceImpl{})
+	require.Nil(t, err)
+	require.ElementsMatch(t, expected.Core(), ctx.Core())
+
+	userService := ctx.MustBean(UserServiceClass).(*userServiceImpl)
+	require.Equal(t, ctx.MustBean(StorageClass), userService.Storage)
+	require.Equal(t, "true", userService.GetConfig("allowWrites"))
+
+	// beans are registered under configured names
+	require.Equal(t, context.BeanSlice{ctx.MustBean(StorageClass)}, ctx.Lookup("storage"))
+	require.Equal(t, context.BeanSlice{userService}, ctx.Lookup("users"))
+
+	registry.RegisterType("MemoryStorage", reflect.TypeOf(memoryStorage{}))
+	registry.RegisterType("NamedConsumer", reflect.TypeOf(namedConsumer{}))
+	ctx, err = context.CreateFromConfig(map[string]string{
+		"primary":   "StorageImpl",
+		"secondary": "MemoryStorage",
+		"consumer":  "NamedConsumer",
+	}, registry)
+	require.Nil(t, err)
+	consumer := ctx.Lookup("consumer")[0].(*namedConsumer)
+	require.Equal(t, ctx.Lookup("primary")[0], consumer.Primary)
+	require.Equal(t, ctx.Lookup("secondary")[0], consumer.Secondary)
+
+}
+
+func TestCreateFromConfigErrors(t *testing.T) {
+
+	context.Verbose = false
+
+	registry := context.NewTypeRegistry()
+	registry.RegisterType("UserService", UserServiceClass)
+
+	_, err := context.CreateFromConfig(map[string]string{"storage": "StorageImpl"}, registry)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "not registered")
+
+	_, err = context.CreateFromConfig(map[string]string{"users": "UserService"}, registry)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "not a struct")
+
+}
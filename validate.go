@@ -0,0 +1,124 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Lifecycle method of the bean with signature that does not match the lifecycle interface, so it would never be called
+ */
+
+type ValidationWarning struct {
+
+	/**
+		Class of the bean
+	 */
+	ClassType reflect.Type
+
+	/**
+		Name of the method
+	 */
+	Method    string
+
+	/**
+		Actual signature of the method, for example 'func()'
+	 */
+	Signature reflect.Type
+
+	/**
+		Signatures that are expected for the method
+	 */
+	Expected  []reflect.Type
+}
+
+func (t ValidationWarning) String() string {
+	expected := make([]string, len(t.Expected))
+	for i, e := range t.Expected {
+		expected[i] = e.String()
+	}
+	return fmt.Sprintf("method '%s' of '%v' has signature '%v', expected %s", t.Method, t.ClassType, t.Signature, strings.Join(expected, " or "))
+}
+
+var lifecycleMethods = []struct {
+	name       string
+	interfaces []reflect.Type
+}{
+	{"PostConstruct", []reflect.Type{
+		reflect.TypeOf((*InitializingBean)(nil)).Elem(),
+		reflect.TypeOf((*ContextInitializingBean)(nil)).Elem(),
+	}},
+	{"Destroy", []reflect.Type{
+		reflect.TypeOf((*DisposableBean)(nil)).Elem(),
+	}},
+	{"Close", []reflect.Type{
+		reflect.TypeOf((*io.Closer)(nil)).Elem(),
+	}},
+}
+
+/**
+	Find lifecycle methods PostConstruct, Destroy and Close with wrong signatures, for example 'PostConstruct()' without error.
+	Go calls lifecycle methods through interfaces, so such methods are silently ignored by the context.
+
+	Example:
+		for _, w := range context.Validate(beans...) {
+			log.Println(w)
+		}
+ */
+func Validate(beans ...interface{}) []ValidationWarning {
+	var list []ValidationWarning
+	for _, obj := range beans {
+		if obj == nil {
+			continue
+		}
+		classPtr := reflect.TypeOf(obj)
+		for _, lm := range lifecycleMethods {
+			if _, ok := classPtr.MethodByName(lm.name); !ok {
+				continue
+			}
+			valid := false
+			var expected []reflect.Type
+			for _, iface := range lm.interfaces {
+				if classPtr.Implements(iface) {
+					valid = true
+					break
+				}
+				im, _ := iface.MethodByName(lm.name)
+				expected = append(expected, im.Type)
+			}
+			if !valid {
+				list = append(list, ValidationWarning{
+					ClassType: classPtr,
+					Method:    lm.name,
+					Signature: reflect.ValueOf(obj).MethodByName(lm.name).Type(),
+					Expected:  expected,
+				})
+			}
+		}
+	}
+	return list
+}
@@ -0,0 +1,65 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type noErrorPostConstruct struct{}
+
+func (t *noErrorPostConstruct) PostConstruct() {}
+
+type extraArgDestroy struct{}
+
+func (t *extraArgDestroy) Destroy(force bool) error { return nil }
+
+type validLifecycle struct{}
+
+func (t *validLifecycle) PostConstruct() error { return nil }
+
+func (t *validLifecycle) Destroy() error { return nil }
+
+func (t *validLifecycle) Close() error { return nil }
+
+func TestValidate(t *testing.T) {
+
+	require.Empty(t, context.Validate(&validLifecycle{}, &cancellableInitBean{}, &storageImpl{}, nil))
+
+	warnings := context.Validate(&noErrorPostConstruct{}, &validLifecycle{}, &extraArgDestroy{})
+	require.Equal(t, 2, len(warnings))
+
+	require.Equal(t, reflect.TypeOf(&noErrorPostConstruct{}), warnings[0].ClassType)
+	require.Equal(t, "PostConstruct", warnings[0].Method)
+	require.Equal(t, "func()", warnings[0].Signature.String())
+	require.Equal(t, 2, len(warnings[0].Expected))
+	require.Contains(t, warnings[0].String(), "expected func() error or func(context.Context) error")
+
+	require.Equal(t, reflect.TypeOf(&extraArgDestroy{}), warnings[1].ClassType)
+	require.Equal(t, "Destroy", warnings[1].Method)
+	require.Equal(t, "func(bool) error", warnings[1].Signature.String())
+
+}
@@ -0,0 +1,68 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"reflect"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Context with runtime key-value metadata, shares core and registry with the original context
+ */
+type valueContext struct {
+	*context
+	metadata map[interface{}]interface{}
+}
+
+func (t *context) With(key, value interface{}) Context {
+	return withValue(t, nil, key, value)
+}
+
+func (t *valueContext) With(key, value interface{}) Context {
+	return withValue(t.context, t.metadata, key, value)
+}
+
+/**
+	Returns the metadata value if found, otherwise the value of the original context
+ */
+func (t *valueContext) Value(key interface{}) interface{} {
+	if value, ok := t.metadata[key]; ok {
+		return value
+	}
+	return t.context.Value(key)
+}
+
+func withValue(ctx *context, metadata map[interface{}]interface{}, key, value interface{}) Context {
+	if key == nil {
+		panic("nil key")
+	}
+	if !reflect.TypeOf(key).Comparable() {
+		panic("key is not comparable")
+	}
+	copied := make(map[interface{}]interface{}, len(metadata) + 1)
+	for k, v := range metadata {
+		copied[k] = v
+	}
+	copied[key] = value
+	return &valueContext{context: ctx, metadata: copied}
+}
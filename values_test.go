@@ -0,0 +1,57 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type requestKey struct{}
+
+func TestWith(t *testing.T) {
+
+	context.Verbose = false
+
+	ctx, err := context.Create(&configStorage{})
+	require.Nil(t, err)
+
+	child := ctx.With("requestID", "abc123")
+	require.Equal(t, "abc123", child.Value("requestID"))
+	require.Nil(t, ctx.Value("requestID"))
+
+	// shares beans with the original context
+	require.Equal(t, ctx.MustBean(StorageClass), child.MustBean(StorageClass))
+	require.Equal(t, ctx.MustBean(StorageClass), child.Value(StorageClass))
+
+	grandChild := child.With(requestKey{}, "alex")
+	require.Equal(t, "abc123", grandChild.Value("requestID"))
+	require.Equal(t, "alex", grandChild.Value(requestKey{}))
+	require.Nil(t, child.Value(requestKey{}))
+
+	require.Panics(t, func() {
+		ctx.With(nil, "value")
+	})
+
+}
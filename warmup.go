@@ -0,0 +1,67 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	"github.com/pkg/errors"
+	"reflect"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Populate cache of bean descriptions used by Inject() for all beans in core or for the given classes,
+	so the first Inject() on runtime does not pay the cost of reflection.
+
+	Example:
+		err := ctx.Warmup(reflect.TypeOf((*requestHandler)(nil)))
+ */
+func (t *context) Warmup(classes ...reflect.Type) error {
+	if len(classes) == 0 {
+		for classPtr, b := range t.coreBeans() {
+			t.runtimeCache.LoadOrStore(classPtr, b.beanDef)
+		}
+		return nil
+	}
+	for _, classPtr := range classes {
+		if classPtr == nil || classPtr.Kind() != reflect.Ptr || classPtr.Elem().Kind() != reflect.Struct {
+			return errors.Errorf("class '%v' is not a pointer to struct", classPtr)
+		}
+		if _, ok := t.runtimeCache.Load(classPtr); ok {
+			continue
+		}
+		b, err := investigate(reflect.New(classPtr.Elem()).Interface(), classPtr)
+		if err != nil {
+			return err
+		}
+		t.runtimeCache.LoadOrStore(classPtr, b.beanDef)
+	}
+	return nil
+}
+
+/**
+	Call Warmup() at the end of Create()
+ */
+func WithWarmup() Option {
+	return func(conf *contextConfig) {
+		conf.warmup = true
+	}
+}
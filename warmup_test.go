@@ -0,0 +1,160 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+/**
+@author Alex Shvid
+*/
+
+type warmHandler struct {
+	Logger        *log.Logger        `inject`
+	Storage       Storage            `inject`
+	ConfigService ConfigService      `inject`
+	UserService   UserService        `inject`
+	Config        *configServiceImpl `inject`
+	method        string
+	path          string
+	query         map[string]string
+	headers       map[string][]string
+	body          []byte
+	user          string
+	locale        string
+	traceID       string
+	startedAt     time.Time
+	timeout       time.Duration
+}
+
+func createWarmContext(tb testing.TB, options ...context.Option) context.Context {
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+	ctx, err := context.CreateWithOptions([]interface{}{
+		logger,
+		&storageImpl{},
+		&configServiceImpl{},
+		&userServiceImpl{},
+		&warmHandler{},
+	}, options...)
+	require.Nil(tb, err)
+	return ctx
+}
+
+func benchmarkFirstInject(b *testing.B, options ...context.Option) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		ctx := createWarmContext(b, options...)
+		handler := &warmHandler{}
+		b.StartTimer()
+		if _, err := ctx.Inject(handler); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkInjectCold(b *testing.B) {
+	benchmarkFirstInject(b)
+}
+
+func BenchmarkInjectWarm(b *testing.B) {
+	benchmarkFirstInject(b, context.WithWarmup())
+}
+
+func TestWarmup(t *testing.T) {
+
+	ctx := createWarmContext(t, context.WithWarmup())
+	handler := &warmHandler{}
+	_, err := ctx.Inject(handler)
+	require.Nil(t, err)
+	require.Equal(t, ctx.MustBean(UserServiceClass), handler.UserService)
+	require.Equal(t, int64(1), ctx.Stats().CacheHits)
+	require.Equal(t, int64(0), ctx.Stats().CacheMisses)
+
+	ctx = createWarmContext(t)
+	require.Nil(t, ctx.Warmup())
+	_, err = ctx.Inject(&warmHandler{})
+	require.Nil(t, err)
+	require.Equal(t, int64(1), ctx.Stats().CacheHits)
+
+	if testing.Verbose() {
+		cold := medianFirstInject(t, 200)
+		warm := medianFirstInject(t, 200, context.WithWarmup())
+		t.Logf("first inject: cold %v, warm %v", cold, warm)
+	}
+
+}
+
+type warmRequest struct {
+	Storage     Storage     `inject`
+	UserService UserService `inject`
+}
+
+func TestWarmupClasses(t *testing.T) {
+
+	ctx := createWarmContext(t)
+	requestClass := reflect.TypeOf((*warmRequest)(nil))
+
+	request := &warmRequest{}
+	_, err := ctx.Inject(request)
+	require.Nil(t, err)
+	require.Equal(t, int64(0), ctx.Stats().CacheHits)
+	require.Equal(t, int64(1), ctx.Stats().CacheMisses)
+
+	ctx = createWarmContext(t)
+	require.Nil(t, ctx.Warmup(requestClass))
+	request = &warmRequest{}
+	_, err = ctx.Inject(request)
+	require.Nil(t, err)
+	require.Equal(t, ctx.MustBean(StorageClass), request.Storage)
+	require.Equal(t, int64(1), ctx.Stats().CacheHits)
+	require.Equal(t, int64(0), ctx.Stats().CacheMisses)
+
+	require.NotNil(t, ctx.Warmup(reflect.TypeOf(warmRequest{})))
+	require.NotNil(t, ctx.Warmup(reflect.TypeOf(&struct {
+		Port int `inject`
+	}{})))
+
+}
+
+/**
+	Median duration of the first Inject() in to new contexts
+ */
+func medianFirstInject(t *testing.T, n int, options ...context.Option) time.Duration {
+	durations := make([]time.Duration, n)
+	for i := range durations {
+		ctx := createWarmContext(t, options...)
+		start := time.Now()
+		_, err := ctx.Inject(&warmHandler{})
+		require.Nil(t, err)
+		durations[i] = time.Since(start)
+	}
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i] < durations[j]
+	})
+	return durations[n/2]
+}
@@ -0,0 +1,99 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context
+
+import (
+	stdcontext "context"
+	"github.com/pkg/errors"
+)
+
+/**
+@author Alex Shvid
+*/
+
+/**
+	Inject fields in to the obj on runtime, same as ctx.Inject(obj).
+	Does not add obj in to the core context.
+ */
+func Wire(ctx Context, obj interface{}) error {
+	_, err := ctx.Inject(obj)
+	return err
+}
+
+/**
+	Same as Inject(), but the type system guarantees that target is a pointer, nil target returns error.
+
+	Example:
+		var rp requestProcessor
+		err := context.TypeSafeInject(ctx, &rp)
+ */
+func TypeSafeInject[T any](ctx Context, target *T) error {
+	if target == nil {
+		return errors.Errorf("null target of type '%v' is not allowed", TokenOf[*T]().Type())
+	}
+	_, err := ctx.Inject(target)
+	return err
+}
+
+/**
+	Create object by constructor and inject fields in to it on runtime.
+	This is the factory of request-scoped objects.
+
+	Example:
+		handler, err := context.WireNew(ctx, func() *RequestHandler {
+			return &RequestHandler{ RequestID: uuid.New() }
+		})
+ */
+func WireNew[T any](ctx Context, constructor func() *T) (*T, error) {
+	obj := constructor()
+	if obj == nil {
+		return nil, errors.Errorf("constructor returned nil of type '%v'", TokenOf[*T]().Type())
+	}
+	if _, err := ctx.Inject(obj); err != nil {
+		return nil, err
+	}
+	trackPrototype(ctx, obj)
+	return obj, nil
+}
+
+/**
+	Create zero value of T, inject fields in to it on runtime and call PostConstruct() if applicable.
+	The object is not added in to the core context.
+
+	Example:
+		handler, err := context.AutoWire[RequestHandler](ctx)
+ */
+func AutoWire[T any](ctx Context) (*T, error) {
+	obj := new(T)
+	if _, err := ctx.Inject(obj); err != nil {
+		return nil, err
+	}
+	var err error
+	switch b := interface{}(obj).(type) {
+	case InitializingBean:
+		err = b.PostConstruct()
+	case ContextInitializingBean:
+		err = b.PostConstruct(stdcontext.Background())
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "initializing %v", TokenOf[*T]().Type())
+	}
+	trackPrototype(ctx, obj)
+	return obj, nil
+}
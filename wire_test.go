@@ -0,0 +1,117 @@
+/*
+ *
+ * Copyright 2020-present Arpabet, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package context_test
+
+import (
+	"github.com/consensusdb/context"
+	"github.com/stretchr/testify/require"
+	"log"
+	"os"
+	"testing"
+)
+
+/**
+@author Alex Shvid
+*/
+
+func createServices(t *testing.T) context.Context {
+	context.Verbose = false
+	logger := log.New(os.Stderr, "context: ", log.LstdFlags)
+	ctx, err := context.Create(
+		logger,
+		&storageImpl{},
+		&configServiceImpl{},
+		&userServiceImpl{},
+	)
+	require.Nil(t, err)
+	return ctx
+}
+
+func TestWire(t *testing.T) {
+
+	ctx := createServices(t)
+
+	controller := &requestScope{requestParams: "username=Alex"}
+	require.Nil(t, context.Wire(ctx, controller))
+	require.Equal(t, ctx.MustBean(UserServiceClass), controller.UserService)
+
+}
+
+func TestWireNew(t *testing.T) {
+
+	ctx := createServices(t)
+
+	controller, err := context.WireNew(ctx, func() *requestScope {
+		return &requestScope{requestParams: "username=Alex"}
+	})
+	require.Nil(t, err)
+	require.Equal(t, "username=Alex", controller.requestParams)
+	require.Equal(t, ctx.MustBean(UserServiceClass), controller.UserService)
+
+	_, err = context.WireNew(ctx, func() *requestScope {
+		return nil
+	})
+	require.NotNil(t, err)
+
+}
+
+type initializedHandler struct {
+	UserService `inject`
+	ready       bool
+}
+
+func (t *initializedHandler) PostConstruct() error {
+	t.ready = t.UserService != nil
+	return nil
+}
+
+func TestAutoWire(t *testing.T) {
+
+	ctx := createServices(t)
+
+	controller, err := context.AutoWire[requestScope](ctx)
+	require.Nil(t, err)
+	require.Equal(t, ctx.MustBean(UserServiceClass), controller.UserService)
+	require.Equal(t, 4, len(ctx.Core()))
+
+	handler, err := context.AutoWire[initializedHandler](ctx)
+	require.Nil(t, err)
+	require.True(t, handler.ready)
+
+	_, err = context.AutoWire[auditedUserService](ctx)
+	require.NotNil(t, err)
+
+}
+
+func TestTypeSafeInject(t *testing.T) {
+
+	ctx := createServices(t)
+
+	var controller requestScope
+	require.Nil(t, context.TypeSafeInject(ctx, &controller))
+	require.Equal(t, ctx.MustBean(UserServiceClass), controller.UserService)
+
+	var missing *requestScope
+	err := context.TypeSafeInject(ctx, missing)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "*context_test.requestScope")
+
+	// context.TypeSafeInject(ctx, controller) does not compile, the target must be a pointer
+
+}